@@ -0,0 +1,67 @@
+package ergo
+
+import (
+	"context"
+	"sync"
+)
+
+// ReportQueueSize is the number of errors waiting to be reported in the background
+// before HandleErrorContext drops them. It is read when the queue is first used.
+var ReportQueueSize = 1024
+
+var (
+	reportQueueOnce sync.Once
+	reportQueue     chan func()
+)
+
+// HandleErrorContext works like HandleError, formatting the error with FormatErrorContext
+// and reporting it in the background so that reporters never add latency to the response.
+// Errors handled with an already canceled context are not reported.
+func HandleErrorContext(ctx context.Context, err error) (int, JSONError) {
+	err = Identify(err)
+	jsonError := FormatErrorContext(ctx, err)
+	if err != nil && ctx.Err() == nil {
+		enqueue(func() { report(err) })
+	}
+	return jsonError.StatusCode, jsonError
+}
+
+// FlushReports waits until the errors queued by HandleErrorContext have been reported,
+// or until the context is done.
+func FlushReports(ctx context.Context) error {
+	done := make(chan struct{})
+	startReportQueue()
+	select {
+	case reportQueue <- func() { close(done) }:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enqueue adds the job to the background queue, dropping it with a diagnostic if the queue is full
+func enqueue(job func()) {
+	startReportQueue()
+	select {
+	case reportQueue <- job:
+	default:
+		OnDiagnostic(&Error{Code: EINTERNAL, Op: "ergo.HandleErrorContext", Message: "Report queue is full."})
+	}
+}
+
+// startReportQueue starts the worker running the background queue
+func startReportQueue() {
+	reportQueueOnce.Do(func() {
+		reportQueue = make(chan func(), ReportQueueSize)
+		go func() {
+			for job := range reportQueue {
+				job()
+			}
+		}()
+	})
+}
@@ -0,0 +1,42 @@
+package ergo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleErrorContext(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	capture := &captureReporter{}
+	defer func() { Reporters = nil }()
+	Reporters = []Reporter{capture}
+
+	// Test with an active context
+	err := &Error{Code: ENOTFOUND}
+	status, jsonError := HandleErrorContext(context.Background(), err)
+	assert.Equal(t, 404, status)
+	assert.Equal(t, FormatError(&Error{Err: err, ErrorID: "err-1"}), jsonError)
+	assert.Equal(t, "err-1", jsonError.ErrorID)
+	assert.NoError(t, FlushReports(context.Background()))
+	assert.Equal(t, []error{&Error{Err: err, ErrorID: "err-1"}}, capture.reported())
+
+	// Test with a canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	status, _ = HandleErrorContext(ctx, &Error{Code: EINVALID})
+	assert.Equal(t, 400, status)
+	assert.NoError(t, FlushReports(context.Background()))
+	assert.Len(t, capture.reported(), 1)
+}
+
+func TestFlushReportsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	block := make(chan struct{})
+	defer close(block)
+	enqueue(func() { <-block })
+
+	assert.Equal(t, context.Canceled, FlushReports(ctx))
+}
@@ -0,0 +1,59 @@
+// Package azure converts ergo errors into the invocation responses of Azure Functions custom handlers.
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/skullflow/ergo"
+	"github.com/skullflow/ergo/internal/recorder"
+)
+
+// DefaultBinding is the name of the HTTP output binding of the function
+const DefaultBinding = "res"
+
+// InvocationResponse is the response of a custom handler to the Functions host
+type InvocationResponse struct {
+	Outputs     map[string]interface{} `json:"Outputs"`
+	Logs        []string               `json:"Logs,omitempty"`
+	ReturnValue interface{}            `json:"ReturnValue,omitempty"`
+}
+
+// HTTPOutput is the value of an HTTP output binding
+type HTTPOutput struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body"`
+}
+
+// FormatInvocationResponse returns the invocation response setting the HTTP output binding
+// to the error, written with ergo.WriteErrorContext, and logging the error to the host.
+// The error is reported.
+func FormatInvocationResponse(ctx context.Context, err error, binding string) InvocationResponse {
+	w := recorder.New()
+	ergo.WriteErrorContext(ctx, w, err)
+
+	response := InvocationResponse{
+		Outputs: map[string]interface{}{
+			binding: HTTPOutput{
+				StatusCode: w.Status,
+				Headers:    w.Headers(),
+				Body:       string(w.Body),
+			},
+		},
+	}
+	if err != nil {
+		response.Logs = []string{err.Error()}
+	}
+	return response
+}
+
+// WriteInvocationError writes to the host the invocation response of the error for the
+// request it sent to the custom handler. The host expects a 200 even for failed invocations.
+func WriteInvocationError(w http.ResponseWriter, r *http.Request, err error, binding string) {
+	body, _ := json.Marshal(FormatInvocationResponse(r.Context(), err, binding))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,31 @@
+package azure
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skullflow/ergo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteInvocationError(t *testing.T) {
+	defer func(generator ergo.IDGenerator) { ergo.DefaultIDGenerator = generator }(ergo.DefaultIDGenerator)
+	ergo.DefaultIDGenerator = ergo.IDGeneratorFunc(func() string { return "err-1" })
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/users", nil)
+	WriteInvocationError(w, r, &ergo.Error{Code: ergo.ENOTFOUND, Op: "users.Get"}, DefaultBinding)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"Outputs": {
+			"res": {
+				"statusCode": 404,
+				"headers": {"Content-Type": "application/json; charset=utf-8", "Cache-Control": "no-store"},
+				"body": "{\"code\":\"not_found\",\"error_id\":\"err-1\",\"code_id\":3,\"status_code\":404,\"message\":\"Resource not found.\"}"
+			}
+		},
+		"Logs": ["users.Get: <not_found>"]
+	}`, w.Body.String())
+}
@@ -0,0 +1,81 @@
+package ergo
+
+import (
+	"sort"
+	"sync"
+)
+
+// BudgetCount defines the cumulative counts of a service route
+// Requests is the number of recorded requests
+// Errors is the number of requests that failed
+// Impacting is the number of errors consuming the error budget
+type BudgetCount struct {
+	Service   string `json:"service"`
+	Route     string `json:"route"`
+	Requests  int    `json:"requests"`
+	Errors    int    `json:"errors"`
+	Impacting int    `json:"impacting"`
+}
+
+type budgetKey struct {
+	service string
+	route   string
+}
+
+// Budget accounts the handled errors against the error budget of each service route.
+// Impacting classifies the errors consuming the budget, by default the ones with a 5xx status code.
+// It is safe for concurrent use.
+type Budget struct {
+	Impacting Matcher
+
+	mu     sync.Mutex
+	counts map[budgetKey]*BudgetCount
+}
+
+// NewBudget returns a Budget counting the 5xx errors as budget-impacting
+func NewBudget() *Budget {
+	return &Budget{
+		Impacting: Is5xx,
+		counts:    make(map[budgetKey]*BudgetCount),
+	}
+}
+
+// Record adds the outcome of a request to the counts of the service route.
+// A nil error records a successful request, suppressed errors never impact the budget.
+func (b *Budget) Record(service string, route string, err error) {
+	impacting := err != nil && b.Impacting(err) && !Suppressed(err)
+	key := budgetKey{service: service, route: route}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	count, ok := b.counts[key]
+	if !ok {
+		count = &BudgetCount{Service: service, Route: route}
+		b.counts[key] = count
+	}
+	count.Requests++
+	if err != nil {
+		count.Errors++
+	}
+	if impacting {
+		count.Impacting++
+	}
+}
+
+// Counts returns the cumulative counts ordered by service and route
+func (b *Budget) Counts() []BudgetCount {
+	b.mu.Lock()
+	counts := make([]BudgetCount, 0, len(b.counts))
+	for _, count := range b.counts {
+		counts = append(counts, *count)
+	}
+	b.mu.Unlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Service != counts[j].Service {
+			return counts[i].Service < counts[j].Service
+		}
+		return counts[i].Route < counts[j].Route
+	})
+	return counts
+}
@@ -0,0 +1,37 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgetRecord(t *testing.T) {
+	budget := NewBudget()
+	budget.Record("billing", "/invoices", nil)
+	budget.Record("billing", "/invoices", &Error{Code: EINVALID})
+	budget.Record("billing", "/invoices", errors.New("some error"))
+	budget.Record("auth", "/login", &Error{Code: EUNAUTHORIZED})
+
+	expected := []BudgetCount{
+		{Service: "auth", Route: "/login", Requests: 1, Errors: 1, Impacting: 0},
+		{Service: "billing", Route: "/invoices", Requests: 3, Errors: 2, Impacting: 1},
+	}
+	assert.Equal(t, expected, budget.Counts())
+}
+
+func TestBudgetImpacting(t *testing.T) {
+	// Test with a custom classification
+	budget := NewBudget()
+	budget.Impacting = func(err error) bool {
+		return ErrorCode(err) == ECONFLICT
+	}
+	budget.Record("billing", "/invoices", &Error{Code: ECONFLICT})
+	budget.Record("billing", "/invoices", &Error{Code: EINTERNAL})
+
+	counts := budget.Counts()
+	assert.Len(t, counts, 1)
+	assert.Equal(t, 2, counts[0].Errors)
+	assert.Equal(t, 1, counts[0].Impacting)
+}
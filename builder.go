@@ -0,0 +1,63 @@
+package ergo
+
+// Builder constructs an error with chained calls, e.g.
+// ergo.New(EINVALID).Op("user.Create").Msg("Email is invalid.").Wrap(err).Build()
+type Builder struct {
+	err Error
+}
+
+// New returns a Builder of an error with the code
+func New(code string) *Builder {
+	return &Builder{err: Error{Code: code}}
+}
+
+// Op sets the operation of the error
+func (b *Builder) Op(op string) *Builder {
+	b.err.Op = op
+	return b
+}
+
+// Msg sets the message of the error
+func (b *Builder) Msg(message string) *Builder {
+	b.err.Message = message
+	return b
+}
+
+// Wrap sets the error wrapped by the error
+func (b *Builder) Wrap(err error) *Builder {
+	b.err.Err = err
+	return b
+}
+
+// Detail sets a key of the details of the error
+func (b *Builder) Detail(key string, value interface{}) *Builder {
+	b.err.setDetail(key, value)
+	return b
+}
+
+// Field sets a key of the fields of the error
+func (b *Builder) Field(key string, value interface{}) *Builder {
+	if b.err.Fields == nil {
+		b.err.Fields = make(map[string]interface{})
+	}
+	b.err.Fields[key] = value
+	return b
+}
+
+// Tag adds the tags to the error
+func (b *Builder) Tag(tags ...string) *Builder {
+	b.err.Tags = append(b.err.Tags, tags...)
+	return b
+}
+
+// Cause sets the root cause classification of the error
+func (b *Builder) Cause(cause string) *Builder {
+	b.err.Cause = cause
+	return b
+}
+
+// Build returns the error, constructed like the other errors of the package.
+// Each call returns a new error, so a Builder can be reused as a template.
+func (b *Builder) Build() *Error {
+	return construct(b.err.Clone())
+}
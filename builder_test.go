@@ -0,0 +1,38 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	cause := errors.New("mail: missing '@'")
+	err := New(EINVALID).
+		Op("user.Create").
+		Msg("Email is invalid.").
+		Wrap(cause).
+		Detail("field", "email").
+		Field("domain", "example").
+		Tag(TagRetryable).
+		Cause("validation").
+		Build()
+
+	assert.Equal(t, &Error{
+		Code:    EINVALID,
+		Message: "Email is invalid.",
+		Op:      "user.Create",
+		Err:     cause,
+		Details: map[string]interface{}{"field": "email"},
+		Cause:   "validation",
+		Tags:    []string{TagRetryable},
+		Fields:  map[string]interface{}{"domain": "example"},
+	}, err)
+
+	// Test with a builder reused as a template
+	builder := New(ENOTFOUND).Op("user.Get")
+	first, second := builder.Detail("id", "1").Build(), builder.Detail("id", "2").Build()
+	assert.Equal(t, "1", first.Details["id"])
+	assert.Equal(t, "2", second.Details["id"])
+}
@@ -0,0 +1,94 @@
+package ergo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// Kinds of the changes between two catalogs of codes
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeID      = "id"
+	ChangeStatus  = "status"
+	ChangeMessage = "message"
+)
+
+// CatalogChange defines a change of a code between two catalogs
+// Breaking reports whether the clients of the previous catalog can be broken by the change
+type CatalogChange struct {
+	Code     string
+	Kind     string
+	Old      interface{}
+	New      interface{}
+	Breaking bool
+}
+
+// String returns the description of the change
+func (c CatalogChange) String() string {
+	switch c.Kind {
+	case ChangeAdded, ChangeRemoved:
+		return fmt.Sprintf("%s: %s", c.Code, c.Kind)
+	}
+	return fmt.Sprintf("%s: %s changed from %v to %v", c.Code, c.Kind, c.Old, c.New)
+}
+
+// CatalogHandler returns a handler serving the catalog of the codes, i.e. Mapping(), as JSON
+func CatalogHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(w).Encode(Mapping())
+	})
+}
+
+// ReadCatalog reads a catalog of codes served by CatalogHandler, or generated with Mapping()
+func ReadCatalog(r io.Reader) (map[string]CodeInfo, error) {
+	var catalog map[string]CodeInfo
+	err := json.NewDecoder(r).Decode(&catalog)
+	return catalog, err
+}
+
+// CompareCatalogs returns the changes of the codes from the old to the updated catalog, sorted by code.
+// Removed codes and changed identifiers, statuses and messages are breaking, added codes are not.
+func CompareCatalogs(old map[string]CodeInfo, updated map[string]CodeInfo) []CatalogChange {
+	var changes []CatalogChange
+	for code, oldInfo := range old {
+		newInfo, ok := updated[code]
+		if !ok {
+			changes = append(changes, CatalogChange{Code: code, Kind: ChangeRemoved, Breaking: true})
+			continue
+		}
+		if oldInfo.ID != newInfo.ID {
+			changes = append(changes, CatalogChange{Code: code, Kind: ChangeID, Old: oldInfo.ID, New: newInfo.ID, Breaking: true})
+		}
+		if oldInfo.Status != newInfo.Status {
+			changes = append(changes, CatalogChange{Code: code, Kind: ChangeStatus, Old: oldInfo.Status, New: newInfo.Status, Breaking: true})
+		}
+		if oldInfo.Message != newInfo.Message {
+			changes = append(changes, CatalogChange{Code: code, Kind: ChangeMessage, Old: oldInfo.Message, New: newInfo.Message, Breaking: true})
+		}
+	}
+	for code := range updated {
+		if _, ok := old[code]; !ok {
+			changes = append(changes, CatalogChange{Code: code, Kind: ChangeAdded})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Code < changes[j].Code
+	})
+	return changes
+}
+
+// BreakingChanges returns the breaking changes among the changes
+func BreakingChanges(changes []CatalogChange) []CatalogChange {
+	var breaking []CatalogChange
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
@@ -0,0 +1,44 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	CatalogHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/errors", nil))
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	catalog, err := ReadCatalog(recorder.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, Mapping(), catalog)
+}
+
+func TestCompareCatalogs(t *testing.T) {
+	old := map[string]CodeInfo{
+		EINVALID:  {ID: 2, Status: 400, Message: "Bad request."},
+		ENOTFOUND: {ID: 3, Status: 404, Message: "Resource not found."},
+		"teapot":  {ID: 100, Status: 418, Message: "I'm a teapot."},
+	}
+	updated := map[string]CodeInfo{
+		EINVALID:  {ID: 2, Status: 422, Message: "Invalid request."},
+		ENOTFOUND: {ID: 3, Status: 404, Message: "Resource not found.", Severity: SeverityInfo},
+		EGONE:     {ID: 14, Status: 410, Message: "Resource has been deleted."},
+	}
+
+	changes := CompareCatalogs(old, updated)
+	assert.Equal(t, []CatalogChange{
+		{Code: EGONE, Kind: ChangeAdded},
+		{Code: EINVALID, Kind: ChangeStatus, Old: 400, New: 422, Breaking: true},
+		{Code: EINVALID, Kind: ChangeMessage, Old: "Bad request.", New: "Invalid request.", Breaking: true},
+		{Code: "teapot", Kind: ChangeRemoved, Breaking: true},
+	}, changes)
+	assert.Len(t, BreakingChanges(changes), 3)
+	assert.Equal(t, "invalid: status changed from 400 to 422", changes[1].String())
+	assert.Equal(t, "teapot: removed", changes[3].String())
+
+	assert.Empty(t, CompareCatalogs(Mapping(), Mapping()))
+}
@@ -0,0 +1,24 @@
+package ergo
+
+// Root causes of the errors, orthogonal to the application error codes
+const (
+	CauseDependencyFailure = "dependency_failure" // A dependency failed or timed out
+	CauseDataCorruption    = "data_corruption"    // Stored data is inconsistent
+	CauseInvalidInput      = "invalid_input"      // Input provided by the caller is invalid
+	CauseCapacity          = "capacity"           // Resources are exhausted
+	CauseAuth              = "auth"               // Authentication or authorization failed
+	CauseUnknown           = "unknown"            // Root cause is not classified
+)
+
+// ErrorCause returns the root cause of the error, if available.
+// Otherwise returns CauseUnknown.
+func ErrorCause(err error) string {
+	if err == nil {
+		return ""
+	} else if e, isCustomError := err.(*Error); isCustomError && e.Cause != "" {
+		return e.Cause
+	} else if isCustomError && e.Err != nil {
+		return ErrorCause(e.Err)
+	}
+	return CauseUnknown
+}
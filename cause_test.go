@@ -0,0 +1,34 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCause(t *testing.T) {
+	// Test with error as nil
+	assert.Equal(t, "", ErrorCause(nil))
+
+	// Test with normal error
+	assert.Equal(t, CauseUnknown, ErrorCause(errors.New("some error")))
+
+	// Test with Cause in Error
+	actual := ErrorCause(&Error{Code: EINTERNAL, Cause: CauseDependencyFailure})
+	assert.Equal(t, CauseDependencyFailure, actual)
+
+	// Test with Cause in wrapped Error
+	actual = ErrorCause(&Error{Op: "user.Get", Err: &Error{Cause: CauseCapacity}})
+	assert.Equal(t, CauseCapacity, actual)
+}
+
+func TestStatsRecordCause(t *testing.T) {
+	stats := NewStats(time.Hour)
+	stats.Record("/users", &Error{Code: EINTERNAL, Cause: CauseDataCorruption})
+
+	records := stats.Records()
+	assert.Len(t, records, 1)
+	assert.Equal(t, CauseDataCorruption, records[0].Cause)
+}
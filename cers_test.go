@@ -2,6 +2,7 @@ package ergo
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -26,6 +27,43 @@ func TestError(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestErrorUnwrap(t *testing.T) {
+	sentinel := errors.New("connection refused")
+	inner := &Error{Code: ENOTFOUND, Message: "User not found.", Err: sentinel}
+
+	// Test with errors.Is and errors.As through the application error
+	err := fmt.Errorf("loading user: %w", &Error{Op: "users.Get", Err: inner})
+	assert.True(t, errors.Is(err, sentinel))
+	var target *Error
+	assert.True(t, errors.As(err, &target))
+	assert.Equal(t, "users.Get", target.Op)
+
+	// Test with the accessors through fmt.Errorf wrappers
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	assert.Equal(t, "User not found.", ErrorMessage(err))
+	assert.Equal(t, http.StatusNotFound, ErrorStatusCode(err))
+
+	// Test with a wrapper of a plain error
+	err = fmt.Errorf("loading user: %w", sentinel)
+	assert.Equal(t, EINTERNAL, ErrorCode(err))
+	assert.Equal(t, "An internal error has occurred.", ErrorMessage(err))
+	assert.Equal(t, http.StatusInternalServerError, ErrorStatusCode(err))
+}
+
+func TestErrorIs(t *testing.T) {
+	err := fmt.Errorf("loading user: %w", &Error{Op: "users.Get", Err: &Error{Code: ENOTFOUND, Message: "User not found."}})
+
+	// Test with a target of the same code
+	assert.True(t, errors.Is(err, &Error{Code: ENOTFOUND}))
+	assert.True(t, errors.Is(err, Sentinel(ENOTFOUND)))
+
+	// Test with a target of another code
+	assert.False(t, errors.Is(err, &Error{Code: EINVALID}))
+
+	// Test with a target without code
+	assert.False(t, errors.Is(err, &Error{Op: "users.Get"}))
+}
+
 func TestErrorCode(t *testing.T) {
 	// Test with error as nil
 	actual := ErrorCode(nil)
@@ -172,6 +210,7 @@ func TestFormatError(t *testing.T) {
 	}
 	expected = JSONError{
 		Code:       EINVALID,
+		CodeID:     2,
 		StatusCode: http.StatusBadRequest,
 		Message:    "message",
 	}
@@ -184,6 +223,7 @@ func TestFormatError(t *testing.T) {
 	}
 	expected = JSONError{
 		Code:       EINVALID,
+		CodeID:     2,
 		StatusCode: http.StatusBadRequest,
 		Message:    "Bad request.",
 	}
@@ -191,7 +231,33 @@ func TestFormatError(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestIdentify(t *testing.T) {
+	defer useFixedIDs("err-1")()
+
+	// Test with nil error
+	assert.Nil(t, Identify(nil))
+
+	// Test with an error without identifier
+	inner := &Error{Code: ENOTFOUND}
+	err := Identify(inner)
+	assert.Equal(t, &Error{Err: inner, ErrorID: "err-1"}, err)
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	assert.Equal(t, "err-1", FormatError(err).ErrorID)
+
+	// Test with an identified error, e.g. received from an upstream service
+	identified := &Error{Op: "user.Get", Err: &Error{Code: ENOTFOUND, ErrorID: "upstream-1"}}
+	assert.Same(t, identified, Identify(identified))
+	assert.Equal(t, "upstream-1", ErrorID(identified))
+
+	// Test with an identified error wrapped with fmt.Errorf
+	wrapped := fmt.Errorf("loading user: %w", identified)
+	assert.Equal(t, "upstream-1", ErrorID(wrapped))
+	assert.Same(t, wrapped, Identify(wrapped))
+	assert.Equal(t, "upstream-1", FormatError(wrapped).ErrorID)
+}
+
 func TestHandleError(t *testing.T) {
+	defer useFixedIDs("err-1")()
 	error := &Error{
 		Code:    EINVALID,
 		Message: "custom message",
@@ -199,6 +265,8 @@ func TestHandleError(t *testing.T) {
 	expectedHttpStatus := http.StatusBadRequest
 	expectedJsonError := JSONError{
 		Code:       EINVALID,
+		ErrorID:    "err-1",
+		CodeID:     2,
 		StatusCode: http.StatusBadRequest,
 		Message:    "custom message",
 	}
@@ -206,3 +274,39 @@ func TestHandleError(t *testing.T) {
 	assert.Equal(t, expectedHttpStatus, actualHttpStatus)
 	assert.Equal(t, expectedJsonError, actualJsonError)
 }
+
+func TestErrorDetails(t *testing.T) {
+	// Test with normal error
+	actual := ErrorDetails(errors.New("some error"))
+	assert.Nil(t, actual)
+
+	// Test without Details
+	actual = ErrorDetails(&Error{Code: EINVALID})
+	assert.Nil(t, actual)
+
+	// Test with Details in the stack, outer ones override
+	error := &Error{
+		Details: map[string]interface{}{"field": "email"},
+		Err: &Error{
+			Details: map[string]interface{}{"field": "name", "max_length": 255},
+		},
+	}
+	expected := map[string]interface{}{"field": "email", "max_length": 255}
+	actual = ErrorDetails(error)
+	assert.Equal(t, expected, actual)
+}
+
+func TestErrorFields(t *testing.T) {
+	// Test with normal error
+	assert.Nil(t, ErrorFields(errors.New("some error")))
+
+	// Test with Fields in the stack, outer ones override
+	error := &Error{
+		Fields: map[string]interface{}{"table": "users"},
+		Err: &Error{
+			Fields: map[string]interface{}{"table": "accounts", "rows": 0},
+		},
+	}
+	expected := map[string]interface{}{"table": "users", "rows": 0}
+	assert.Equal(t, expected, ErrorFields(error))
+}
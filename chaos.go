@@ -0,0 +1,81 @@
+package ergo
+
+import (
+	"math/rand"
+	"net/http"
+	"strings"
+)
+
+// Injection defines an error injected by Chaos in the requests whose path starts with Route,
+// in Percent percent of them.
+type Injection struct {
+	Route   string
+	Percent float64
+	Err     error
+}
+
+// Chaos is a middleware injecting errors in the requests, for chaos testing the clients.
+// The errors are served with ServeError, so they have the envelope of the real errors.
+// Injections are checked in order, and the requests carrying a code in TriggerHeader,
+// if not empty, receive an error with that code. The trigger is gated: only the trusted
+// requests, carrying the TrustedSecret in the TrustedHeader, can use it, and only with
+// a code of the registry or of the injections, so that clients cannot choose the errors
+// of the service. Random draws the percentages, rand.Float64 if nil.
+type Chaos struct {
+	Injections    []Injection
+	TriggerHeader string
+	Random        func() float64
+}
+
+// NewChaos returns a Chaos injecting the errors of the injections
+func NewChaos(injections ...Injection) *Chaos {
+	return &Chaos{Injections: injections, Random: rand.Float64}
+}
+
+// Handler returns the middleware injecting the errors in place of next
+func (c *Chaos) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := c.inject(r); err != nil {
+			ServeError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// inject returns the error to inject in the request, or nil
+func (c *Chaos) inject(r *http.Request) error {
+	if c.TriggerHeader != "" && trusted(r) {
+		if code := r.Header.Get(c.TriggerHeader); code != "" && c.triggerable(code) {
+			return &Error{Code: code, Op: "ergo.Chaos"}
+		}
+	}
+	for _, injection := range c.Injections {
+		if strings.HasPrefix(r.URL.Path, injection.Route) && c.random()*100 < injection.Percent {
+			return injection.Err
+		}
+	}
+	return nil
+}
+
+// random returns a number in [0, 1) drawn from Random, or from rand.Float64 if nil
+func (c *Chaos) random() float64 {
+	if c.Random == nil {
+		return rand.Float64()
+	}
+	return c.Random()
+}
+
+// triggerable reports whether the code can be triggered with the TriggerHeader,
+// i.e. is present in the registry or is the code of an injection
+func (c *Chaos) triggerable(code string) bool {
+	if _, ok := policy().Codes[code]; ok {
+		return true
+	}
+	for _, injection := range c.Injections {
+		if ErrorCode(injection.Err) == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package ergo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaos(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	chaos := NewChaos(
+		Injection{Route: "/payments", Percent: 50, Err: &Error{Code: EINTERNAL}},
+		Injection{Route: "/users", Percent: 100, Err: &Error{Code: ETOOMANYREQUESTS}},
+	)
+	chaos.TriggerHeader = "Ergo-Chaos"
+	random := 0.7
+	chaos.Random = func() float64 { return random }
+	handler := chaos.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer func() { TrustedSecret = "" }()
+	TrustedSecret = "s3cret"
+	trustedRequest := true
+	serve := func(path string, code string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", path, nil)
+		if code != "" {
+			r.Header.Set("Ergo-Chaos", code)
+		}
+		if trustedRequest {
+			r.Header.Set(TrustedHeader, TrustedSecret)
+		}
+		handler.ServeHTTP(recorder, r)
+		return recorder
+	}
+
+	// Test with a percentage
+	assert.Equal(t, 204, serve("/payments", "").Code)
+	random = 0.3
+	assert.Equal(t, 500, serve("/payments", "").Code)
+
+	// Test with a route
+	assert.Equal(t, 429, serve("/users/42", "").Code)
+	assert.Equal(t, 204, serve("/orders", "").Code)
+
+	// Test with the trigger header
+	recorder := serve("/orders", ENOTFOUND)
+	assert.Equal(t, 404, recorder.Code)
+	assert.JSONEq(t, `{"code":"not_found","error_id":"err-1","code_id":3,"status_code":404,"message":"Resource not found.","root_cause":"ergo.Chaos: <not_found>"}`, recorder.Body.String())
+
+	// Test with a code neither registered nor injected
+	assert.Equal(t, 204, serve("/orders", "made_up").Code)
+
+	// Test with a code of an injection
+	chaos.Injections = append(chaos.Injections, Injection{Route: "/legacy", Err: &Error{Code: "legacy_failure"}})
+	assert.Equal(t, 500, serve("/orders", "legacy_failure").Code)
+
+	// Test with an untrusted request
+	trustedRequest = false
+	assert.Equal(t, 204, serve("/orders", ENOTFOUND).Code)
+
+	// Test with a literal middleware, without Random
+	literal := &Chaos{Injections: []Injection{{Route: "/users", Percent: 100, Err: &Error{Code: ETOOMANYREQUESTS}}}}
+	recorder = httptest.NewRecorder()
+	literal.Handler(http.NotFoundHandler()).ServeHTTP(recorder, httptest.NewRequest("GET", "/users", nil))
+	assert.Equal(t, 429, recorder.Code)
+}
@@ -0,0 +1,20 @@
+package ergo
+
+// StatusClass returns the class of the status code of the error, e.g. 4 for 4xx.
+// It returns 0 if err is nil.
+func StatusClass(err error) int {
+	if err == nil {
+		return 0
+	}
+	return ErrorStatusCode(err) / 100
+}
+
+// Is4xx reports whether the error is reported with a client error status code
+func Is4xx(err error) bool {
+	return StatusClass(err) == 4
+}
+
+// Is5xx reports whether the error is reported with a server error status code
+func Is5xx(err error) bool {
+	return StatusClass(err) == 5
+}
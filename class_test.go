@@ -0,0 +1,26 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, 0, StatusClass(nil))
+	assert.Equal(t, 5, StatusClass(errors.New("some error")))
+	assert.Equal(t, 4, StatusClass(&Error{Code: ENOTFOUND}))
+}
+
+func TestIs4xx(t *testing.T) {
+	assert.False(t, Is4xx(nil))
+	assert.True(t, Is4xx(&Error{Code: EINVALID}))
+	assert.False(t, Is4xx(&Error{Code: EINTERNAL}))
+}
+
+func TestIs5xx(t *testing.T) {
+	assert.False(t, Is5xx(nil))
+	assert.True(t, Is5xx(errors.New("some error")))
+	assert.False(t, Is5xx(&Error{Code: EFORBIDDEN}))
+}
@@ -0,0 +1,46 @@
+package ergo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Clock provides the time of the errors, events and windows of the package
+type Clock interface {
+	Now() time.Time
+}
+
+// IDGenerator provides the identifiers of the reported events
+type IDGenerator interface {
+	NewID() string
+}
+
+// DefaultClock is the clock of the package, replace it with a fake clock to make
+// the timestamps deterministic in tests.
+var DefaultClock Clock = systemClock{}
+
+// DefaultIDGenerator generates the error_id of the handled errors, sent to the clients and
+// to the reporters, replace it with a sequence to make the identifiers deterministic in tests.
+var DefaultIDGenerator IDGenerator = randomIDs{}
+
+// systemClock is the clock of the system
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// randomIDs generates random 128-bit identifiers, hex encoded
+type randomIDs struct{}
+
+func (randomIDs) NewID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// now returns the current time of the DefaultClock
+func now() time.Time {
+	return DefaultClock.Now()
+}
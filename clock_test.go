@@ -0,0 +1,48 @@
+package ergo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+type fixedIDs string
+
+func (id fixedIDs) NewID() string {
+	return string(id)
+}
+
+func TestDefaultClock(t *testing.T) {
+	defer func() {
+		DefaultClock = systemClock{}
+		DefaultIDGenerator = randomIDs{}
+	}()
+	DefaultClock = fixedClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	DefaultIDGenerator = fixedIDs("evt-1")
+
+	event := NewEvent(&Error{Code: EINVALID})
+	assert.Equal(t, "evt-1", event.ID)
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), event.Time)
+}
+
+func TestRandomIDs(t *testing.T) {
+	id := randomIDs{}.NewID()
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, randomIDs{}.NewID())
+}
+
+// useFixedIDs makes the DefaultIDGenerator return id, returning the function restoring it
+func useFixedIDs(id string) func() {
+	generator := DefaultIDGenerator
+	DefaultIDGenerator = fixedIDs(id)
+	return func() {
+		DefaultIDGenerator = generator
+	}
+}
@@ -0,0 +1,129 @@
+package ergo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CloudTraceHeader is the header carrying the trace context on Google Cloud
+const CloudTraceHeader = "X-Cloud-Trace-Context"
+
+// cloudReportedErrorEvent is the type making Error Reporting group the log entries
+const cloudReportedErrorEvent = "type.googleapis.com/google.devtools.clouderrorreporting.v1beta1.ReportedErrorEvent"
+
+// CloudLogging is a Reporter writing the handled errors as JSON lines in the structured
+// logging format of Google Cloud, as read from the standard output of Cloud Run and
+// Cloud Functions. The entries are typed as reported error events, so Error Reporting
+// groups them, and are correlated with the trace id of the error, if any.
+// It is safe for concurrent use.
+type CloudLogging struct {
+	Writer    io.Writer
+	ProjectID string
+
+	mu sync.Mutex
+}
+
+type cloudEntry struct {
+	Severity       string               `json:"severity"`
+	Message        string               `json:"message"`
+	Type           string               `json:"@type"`
+	Time           time.Time            `json:"time"`
+	Trace          string               `json:"logging.googleapis.com/trace,omitempty"`
+	ServiceContext *cloudServiceContext `json:"serviceContext,omitempty"`
+	Code           string               `json:"code"`
+	Op             string               `json:"op,omitempty"`
+	StatusCode     int                  `json:"status_code"`
+	Cause          string               `json:"cause"`
+	StackTrace     string               `json:"stack_trace,omitempty"`
+	Context        *cloudContext        `json:"context,omitempty"`
+}
+
+type cloudContext struct {
+	ReportLocation cloudLocation `json:"reportLocation"`
+}
+
+type cloudLocation struct {
+	FilePath     string `json:"filePath,omitempty"`
+	LineNumber   int    `json:"lineNumber,omitempty"`
+	FunctionName string `json:"functionName"`
+}
+
+type cloudServiceContext struct {
+	Service string `json:"service"`
+	Version string `json:"version,omitempty"`
+}
+
+// NewCloudLogging returns a CloudLogging writing to w for the project
+func NewCloudLogging(w io.Writer, projectID string) *CloudLogging {
+	return &CloudLogging{Writer: w, ProjectID: projectID}
+}
+
+// Report writes the log entry of the error, with severity ERROR for 5xx errors and WARNING otherwise.
+// The stack of the error is written in the format of the Go panics, as parsed by Error Reporting,
+// and the errors without stack are located by their op.
+func (c *CloudLogging) Report(err error) {
+	entry := cloudEntry{
+		Severity:   "WARNING",
+		Message:    err.Error(),
+		Type:       cloudReportedErrorEvent,
+		Time:       now().UTC(),
+		Code:       ErrorCode(err),
+		Op:         errorOp(err),
+		StatusCode: ErrorStatusCode(err),
+		Cause:      ErrorCause(err),
+	}
+	if entry.StatusCode >= http.StatusInternalServerError {
+		entry.Severity = "ERROR"
+	}
+	if traceID := errorTraceID(err); traceID != "" && c.ProjectID != "" {
+		entry.Trace = "projects/" + c.ProjectID + "/traces/" + traceID
+	}
+	if origin := serviceOrigin(); origin != nil {
+		entry.ServiceContext = &cloudServiceContext{Service: origin.Service, Version: origin.Version}
+	}
+	if stack := ErrorStack(err); len(stack) > 0 {
+		entry.StackTrace = cloudStackTrace(entry.Message, stack)
+	} else if entry.Op != "" {
+		entry.Context = &cloudContext{ReportLocation: cloudLocation{FunctionName: entry.Op}}
+	}
+
+	line, _ := json.Marshal(entry)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = c.Writer.Write(append(line, '\n'))
+}
+
+// CloudTraceID returns the trace id of the X-Cloud-Trace-Context header of the request,
+// e.g. to set it with WithField(ctx, FieldTraceID, CloudTraceID(r)).
+func CloudTraceID(r *http.Request) string {
+	value := r.Header.Get(CloudTraceHeader)
+	if i := strings.IndexByte(value, '/'); i >= 0 {
+		value = value[:i]
+	}
+	return value
+}
+
+// cloudStackTrace returns the message and the stack in the format of the goroutine traces
+func cloudStackTrace(message string, stack []Frame) string {
+	var trace strings.Builder
+	trace.WriteString(message)
+	trace.WriteString("\n\ngoroutine 1 [running]:\n")
+	for _, frame := range stack {
+		trace.WriteString(frame.Function + "(...)\n\t" + frame.File + ":" + strconv.Itoa(frame.Line) + "\n")
+	}
+	return trace.String()
+}
+
+// errorTraceID returns the trace id of the error, from its details or its fields
+func errorTraceID(err error) string {
+	if traceID := detailString(err, FieldTraceID); traceID != "" {
+		return traceID
+	}
+	traceID, _ := ErrorFields(err)[FieldTraceID].(string)
+	return traceID
+}
@@ -0,0 +1,67 @@
+package ergo
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudLogging(t *testing.T) {
+	defer func() { ServiceOrigin = Origin{} }()
+	ServiceOrigin = Origin{Service: "users", Version: "1.2.0"}
+	var buffer bytes.Buffer
+	logging := NewCloudLogging(&buffer, "acme")
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(CloudTraceHeader, "105445aa7843bc8bf206b12000100000/1;o=1")
+	ctx := WithField(r.Context(), FieldTraceID, CloudTraceID(r))
+	err := NewCtx(ctx, EINTERNAL, "Database is down.")
+	err.Op = "users.Create"
+	err.Stack = []Frame{
+		{Function: "acme/users.(*Service).Create", File: "/src/users/service.go", Line: 42},
+		{Function: "main.main", File: "/src/main.go", Line: 12},
+	}
+	logging.Report(err)
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &entry))
+	delete(entry, "time")
+	assert.Equal(t, map[string]interface{}{
+		"severity":                     "ERROR",
+		"message":                      "users.Create: <internal>Database is down.",
+		"@type":                        "type.googleapis.com/google.devtools.clouderrorreporting.v1beta1.ReportedErrorEvent",
+		"logging.googleapis.com/trace": "projects/acme/traces/105445aa7843bc8bf206b12000100000",
+		"serviceContext":               map[string]interface{}{"service": "users", "version": "1.2.0"},
+		"code":                         "internal",
+		"op":                           "users.Create",
+		"status_code":                  float64(500),
+		"cause":                        CauseUnknown,
+		"stack_trace": "users.Create: <internal>Database is down.\n\ngoroutine 1 [running]:\n" +
+			"acme/users.(*Service).Create(...)\n\t/src/users/service.go:42\n" +
+			"main.main(...)\n\t/src/main.go:12\n",
+	}, entry)
+
+	// Test with a client error without trace
+	buffer.Reset()
+	logging.Report(&Error{Code: EINVALID, Op: "users.Update"})
+	entry = nil
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &entry))
+	assert.Equal(t, "WARNING", entry["severity"])
+	assert.NotContains(t, buffer.String(), "logging.googleapis.com/trace")
+
+	// Test with an error without stack, located by its op
+	assert.Nil(t, entry["stack_trace"])
+	assert.Equal(t, map[string]interface{}{
+		"reportLocation": map[string]interface{}{"functionName": "users.Update"},
+	}, entry["context"])
+}
+
+func TestCloudTraceID(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	assert.Equal(t, "", CloudTraceID(r))
+	r.Header.Set(CloudTraceHeader, "abc")
+	assert.Equal(t, "abc", CloudTraceID(r))
+}
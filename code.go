@@ -0,0 +1,33 @@
+package ergo
+
+// Code is an application error code. Declaring the codes of an application as typed
+// constants, e.g. const EBILLING ergo.Code = "billing_failed", lets the compiler catch
+// misspelled codes. The codes of the package are untyped constants, so they can be used
+// both as plain strings and as Code.
+type Code string
+
+// String returns the code as a plain string
+func (c Code) String() string {
+	return string(c)
+}
+
+// Valid reports whether the code is present in the registry
+func (c Code) Valid() bool {
+	_, ok := policy().Codes[string(c)]
+	return ok
+}
+
+// Status returns the status code of the errors with the code, 500 for unregistered codes
+func (c Code) Status() int {
+	return ErrorStatusCode(&Error{Code: string(c)})
+}
+
+// DefaultMessage returns the message of the errors with the code without message
+func (c Code) DefaultMessage() string {
+	return ErrorMessage(&Error{Code: string(c)})
+}
+
+// New returns an error with the code and the message
+func (c Code) New(message string) *Error {
+	return construct(&Error{Code: string(c), Message: message})
+}
@@ -0,0 +1,26 @@
+package ergo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ETEAPOT Code = "teapot"
+
+func TestCode(t *testing.T) {
+	var code Code = ENOTFOUND
+	assert.Equal(t, "not_found", code.String())
+	assert.True(t, code.Valid())
+	assert.Equal(t, 404, code.Status())
+	assert.Equal(t, "Resource not found.", code.DefaultMessage())
+
+	err := code.New("User not found.")
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	assert.Equal(t, "User not found.", ErrorMessage(err))
+
+	// Test with an unregistered code
+	assert.False(t, ETEAPOT.Valid())
+	assert.Equal(t, 500, ETEAPOT.Status())
+	assert.Equal(t, "An internal error has occurred.", ETEAPOT.DefaultMessage())
+}
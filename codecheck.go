@@ -0,0 +1,59 @@
+package ergo
+
+import "fmt"
+
+// Policies of the errors with a code not present in the registry
+const (
+	UnregisteredAllow   = iota // Send the code as is
+	UnregisteredDegrade        // Send an internal error and report a diagnostic, e.g. in production
+	UnregisteredPanic          // Panic when constructing or formatting the error, e.g. in development and tests
+)
+
+// UnregisteredCodes is the policy of the errors with a code not present in the registry,
+// to catch the misspelled codes before they reach the clients.
+var UnregisteredCodes = UnregisteredAllow
+
+// ValidateCode returns an error if the code is not present in the registry
+func ValidateCode(code string) error {
+	return validateCode(policy(), code)
+}
+
+// validateCode returns an error if the code is not present in the registry of the policy
+func validateCode(p *Config, code string) error {
+	if _, ok := p.Codes[code]; ok || code == "" {
+		return nil
+	}
+	return fmt.Errorf("ergo: code %q is not registered", code)
+}
+
+// checkCode panics if the policy is UnregisteredPanic and the code is not registered
+func checkCode(code string) {
+	if UnregisteredCodes != UnregisteredPanic {
+		return
+	}
+	if err := ValidateCode(code); err != nil {
+		panic(err)
+	}
+}
+
+// checkFormattedCode applies the policy of the unregistered codes to the formatted error
+func checkFormattedCode(p *Config, jsonError JSONError) JSONError {
+	if UnregisteredCodes == UnregisteredAllow {
+		return jsonError
+	}
+	err := validateCode(p, jsonError.Code)
+	if err == nil {
+		return jsonError
+	}
+	if UnregisteredCodes == UnregisteredPanic {
+		panic(err)
+	}
+
+	OnDiagnostic(&Error{Code: EINTERNAL, Op: "ergo.FormatError", Err: err})
+	jsonError.Code = EINTERNAL
+	jsonError.CodeID = p.Codes[EINTERNAL].ID
+	jsonError.StatusCode = errorStatusCode(p, &Error{Code: EINTERNAL})
+	jsonError.Message = errorMessage(p, &Error{Code: EINTERNAL})
+	jsonError.Details = nil
+	return jsonError
+}
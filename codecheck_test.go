@@ -0,0 +1,36 @@
+package ergo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnregisteredCodes(t *testing.T) {
+	var diagnostics []error
+	defer func() {
+		UnregisteredCodes = UnregisteredAllow
+		OnDiagnostic = func(diagnostic error) {}
+	}()
+	OnDiagnostic = func(diagnostic error) {
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	misspelled := &Error{Code: "not_foud", Message: "User not found.", Details: map[string]interface{}{"id": 42}}
+
+	// Test with the default policy
+	assert.Equal(t, "not_foud", FormatError(misspelled).Code)
+	assert.NoError(t, ValidateCode(ENOTFOUND))
+	assert.EqualError(t, ValidateCode("not_foud"), `ergo: code "not_foud" is not registered`)
+
+	// Test with the degrade policy
+	UnregisteredCodes = UnregisteredDegrade
+	assert.Equal(t, JSONError{Code: EINTERNAL, CodeID: 1, StatusCode: 500, Message: "An internal error has occurred."}, FormatError(misspelled))
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, ENOTFOUND, FormatError(&Error{Code: ENOTFOUND}).Code)
+
+	// Test with the panic policy
+	UnregisteredCodes = UnregisteredPanic
+	assert.Panics(t, func() { FormatError(misspelled) })
+	assert.Panics(t, func() { Code("not_foud").New("User not found.") })
+	assert.NotPanics(t, func() { FormatError(nil) })
+}
@@ -0,0 +1,222 @@
+package ergo
+
+import (
+	"context"
+	"net/http"
+)
+
+// CodeInfo defines how an application error code is reported
+// ID is a stable numeric identifier of the code, 0 if not assigned
+// Status is the status code of the http request
+// Message is the end-user message, used when the error has no message
+// DeveloperMessage describes the error to developers integrating the API
+// Retryable reports whether the request can be retried as is
+// Severity is the severity of the errors with the code in the logs
+type CodeInfo struct {
+	ID               int    `json:"id"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	DeveloperMessage string `json:"developer_message,omitempty"`
+	Retryable        bool   `json:"retryable"`
+	Severity         string `json:"severity,omitempty"`
+}
+
+// Severities of the error codes
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Codes is the registry of the application error codes.
+// Errors with a code not present in the registry are reported as internal errors.
+var Codes = map[string]CodeInfo{
+	ECONFLICT: {
+		ID:               4,
+		Status:           http.StatusConflict,
+		Message:          "Conflict error.",
+		DeveloperMessage: "The action conflicts with the current state of the resource.",
+		Severity:         SeverityWarning,
+	},
+	EINTERNAL: {
+		ID:               1,
+		Status:           http.StatusInternalServerError,
+		Message:          "An internal error has occurred.",
+		DeveloperMessage: "The server failed to process the request.",
+		Severity:         SeverityError,
+	},
+	EINVALID: {
+		ID:               2,
+		Status:           http.StatusBadRequest,
+		Message:          "Bad request.",
+		DeveloperMessage: "The request failed validation.",
+		Severity:         SeverityWarning,
+	},
+	ENOTFOUND: {
+		ID:               3,
+		Status:           http.StatusNotFound,
+		Message:          "Resource not found.",
+		DeveloperMessage: "The requested resource does not exist.",
+		Severity:         SeverityInfo,
+	},
+	EUNAUTHORIZED: {
+		ID:               5,
+		Status:           http.StatusUnauthorized,
+		Message:          "Unauthorized.",
+		DeveloperMessage: "The request lacks valid authentication credentials.",
+		Severity:         SeverityWarning,
+	},
+	EFORBIDDEN: {
+		ID:               6,
+		Status:           http.StatusForbidden,
+		Message:          "Forbidden.",
+		DeveloperMessage: "The credentials do not grant access to the resource.",
+		Severity:         SeverityWarning,
+	},
+	EPRECONDITION: {
+		ID:               7,
+		Status:           http.StatusPreconditionFailed,
+		Message:          "Precondition failed.",
+		DeveloperMessage: "The If-Match or If-Unmodified-Since precondition does not match the resource.",
+		Severity:         SeverityWarning,
+	},
+	EPRECONDITIONREQUIRED: {
+		ID:               8,
+		Status:           http.StatusPreconditionRequired,
+		Message:          "Precondition required.",
+		DeveloperMessage: "The request must be conditional, send If-Match or If-Unmodified-Since.",
+		Severity:         SeverityWarning,
+	},
+	ERANGE: {
+		ID:               9,
+		Status:           http.StatusRequestedRangeNotSatisfiable,
+		Message:          "Range not satisfiable.",
+		DeveloperMessage: "The requested range does not overlap the resource.",
+		Severity:         SeverityWarning,
+	},
+	EPAYLOADTOOLARGE: {
+		ID:               10,
+		Status:           http.StatusRequestEntityTooLarge,
+		Message:          "Payload too large.",
+		DeveloperMessage: "The request body exceeds the size limit.",
+		Severity:         SeverityWarning,
+	},
+	EUNSUPPORTEDMEDIA: {
+		ID:               11,
+		Status:           http.StatusUnsupportedMediaType,
+		Message:          "Unsupported media type.",
+		DeveloperMessage: "The Content-Type of the request body is not supported.",
+		Severity:         SeverityWarning,
+	},
+	ETOOMANYREQUESTS: {
+		ID:               12,
+		Status:           http.StatusTooManyRequests,
+		Message:          "Too many requests.",
+		DeveloperMessage: "The rate limit is exceeded, retry after the reset time.",
+		Retryable:        true,
+		Severity:         SeverityWarning,
+	},
+	EIDEMPOTENCY: {
+		ID:               13,
+		Status:           http.StatusUnprocessableEntity,
+		Message:          "Idempotency key already used.",
+		DeveloperMessage: "The idempotency key was already used with a different request.",
+		Severity:         SeverityWarning,
+	},
+	EGONE: {
+		ID:               14,
+		Status:           http.StatusGone,
+		Message:          "Resource has been deleted.",
+		DeveloperMessage: "The requested resource existed but has been deleted.",
+		Severity:         SeverityInfo,
+	},
+	EUNAVAILABLE: {
+		ID:               15,
+		Status:           http.StatusServiceUnavailable,
+		Message:          "Service unavailable.",
+		DeveloperMessage: "The service is temporarily unavailable, retry later.",
+		Retryable:        true,
+		Severity:         SeverityError,
+	},
+	EPAYMENTREQUIRED: {
+		ID:               16,
+		Status:           http.StatusPaymentRequired,
+		Message:          "Payment required.",
+		DeveloperMessage: "The plan of the account does not allow the action, upgrade it to proceed.",
+		Severity:         SeverityInfo,
+	},
+	ETIMEOUT: {
+		ID:               17,
+		Status:           http.StatusGatewayTimeout,
+		Message:          "The operation timed out.",
+		DeveloperMessage: "The operation did not complete within its time budget.",
+		Retryable:        true,
+		Severity:         SeverityError,
+	},
+	EMETHODNOTALLOWED: {
+		ID:               18,
+		Status:           http.StatusMethodNotAllowed,
+		Message:          "Method not allowed.",
+		DeveloperMessage: "The method is not supported by the resource, see the allowed methods.",
+		Severity:         SeverityWarning,
+	},
+	EPOLICYVIOLATION: {
+		ID:               19,
+		Status:           http.StatusUnprocessableEntity,
+		Message:          "The content violates our policies.",
+		DeveloperMessage: "The content was rejected by the moderation, see the verdicts of the items.",
+		Severity:         SeverityInfo,
+	},
+}
+
+// Audiences of the error messages
+const (
+	AudienceUser      = "user"      // End-users, receive the message of the code
+	AudienceDeveloper = "developer" // Developers, receive the message of the error
+)
+
+type audienceKey struct{}
+
+// WithAudience returns a copy of the context carrying the audience of the error messages
+func WithAudience(ctx context.Context, audience string) context.Context {
+	return context.WithValue(ctx, audienceKey{}, audience)
+}
+
+// ErrorMessageContext returns the message of the error for the audience of the context.
+// Without audience it works like ErrorMessage.
+func ErrorMessageContext(ctx context.Context, err error) string {
+	return errorMessageContext(contextPolicy(ctx), ctx, err)
+}
+
+// errorMessageContext returns the message of the error for the audience of the context
+// under the policy
+func errorMessageContext(p *Config, ctx context.Context, err error) string {
+	if err == nil {
+		return ""
+	}
+	audience, _ := ctx.Value(audienceKey{}).(string)
+	switch audience {
+	case AudienceUser:
+		if message := p.Codes[errorCode(p, err)].Message; message != "" {
+			return message
+		}
+		return p.Codes[EINTERNAL].Message
+	case AudienceDeveloper:
+		if !hasMessage(err) {
+			if message := p.Codes[errorCode(p, err)].DeveloperMessage; message != "" {
+				return message
+			}
+		}
+	}
+	return errorMessage(p, err)
+}
+
+// hasMessage reports whether an error of the stack has a message
+func hasMessage(err error) bool {
+	if e, isCustomError := err.(*Error); isCustomError && e.Message != "" {
+		return true
+	} else if isCustomError && e.Err != nil {
+		return hasMessage(e.Err)
+	}
+	return false
+}
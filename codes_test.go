@@ -0,0 +1,61 @@
+package ergo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodes(t *testing.T) {
+	defer delete(Codes, "teapot")
+	Codes["teapot"] = CodeInfo{
+		Status:  http.StatusTeapot,
+		Message: "I'm a teapot.",
+	}
+
+	error := &Error{Code: "teapot"}
+	assert.Equal(t, http.StatusTeapot, ErrorStatusCode(error))
+	assert.Equal(t, "I'm a teapot.", ErrorMessage(error))
+
+	// Test with a code not registered
+	error = &Error{Code: "unregistered"}
+	assert.Equal(t, http.StatusInternalServerError, ErrorStatusCode(error))
+	assert.Equal(t, "An internal error has occurred.", ErrorMessage(error))
+}
+
+func TestErrorMessageContext(t *testing.T) {
+	error := &Error{Code: EINTERNAL, Message: "upstream billing service timeout"}
+
+	// Test with nil error
+	assert.Equal(t, "", ErrorMessageContext(context.Background(), nil))
+
+	// Test without audience
+	actual := ErrorMessageContext(context.Background(), error)
+	assert.Equal(t, "upstream billing service timeout", actual)
+
+	// Test with user audience
+	ctx := WithAudience(context.Background(), AudienceUser)
+	actual = ErrorMessageContext(ctx, error)
+	assert.Equal(t, "An internal error has occurred.", actual)
+	actual = ErrorMessageContext(ctx, errors.New("some error"))
+	assert.Equal(t, "An internal error has occurred.", actual)
+
+	// Test with developer audience
+	ctx = WithAudience(context.Background(), AudienceDeveloper)
+	actual = ErrorMessageContext(ctx, error)
+	assert.Equal(t, "upstream billing service timeout", actual)
+	actual = ErrorMessageContext(ctx, &Error{Code: EINVALID})
+	assert.Equal(t, "The request failed validation.", actual)
+}
+
+func TestFormatErrorCodeID(t *testing.T) {
+	actual := FormatError(&Error{Code: ENOTFOUND})
+	assert.Equal(t, 3, actual.CodeID)
+
+	// Test with a code without numeric identifier
+	actual = FormatError(&Error{Code: "unregistered"})
+	assert.Equal(t, 0, actual.CodeID)
+}
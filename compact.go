@@ -0,0 +1,53 @@
+package ergo
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CompactMediaType is the media type clients accept to receive compact errors
+const CompactMediaType = "application/vnd.ergo.compact+json"
+
+// CompactError defines the compact error to send to clients on poor networks
+// ID is the numeric identifier of the code
+// Code is only sent when the code has no numeric identifier
+type CompactError struct {
+	ID         int                    `json:"i,omitempty"`
+	Code       string                 `json:"c,omitempty"`
+	StatusCode int                    `json:"s"`
+	Message    string                 `json:"m"`
+	Details    map[string]interface{} `json:"d,omitempty"`
+}
+
+// FormatCompactError returns the compact representation of the Json error
+func FormatCompactError(jsonError JSONError) CompactError {
+	compactError := CompactError{
+		ID:         jsonError.CodeID,
+		StatusCode: jsonError.StatusCode,
+		Message:    jsonError.Message,
+		Details:    jsonError.Details,
+	}
+	if compactError.ID == 0 {
+		compactError.Code = jsonError.Code
+	}
+	return compactError
+}
+
+// acceptsCompact reports whether the Accept header of the request lists CompactMediaType
+// with a positive q-value
+func acceptsCompact(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil || mediaType != CompactMediaType {
+			continue
+		}
+		q := 1.0
+		if parsed, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			q = parsed
+		}
+		return q > 0
+	}
+	return false
+}
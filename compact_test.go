@@ -0,0 +1,56 @@
+package ergo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatCompactError(t *testing.T) {
+	actual := FormatCompactError(FormatError(&Error{Code: ENOTFOUND}))
+	expected := CompactError{
+		ID:         3,
+		StatusCode: http.StatusNotFound,
+		Message:    "Resource not found.",
+	}
+	assert.Equal(t, expected, actual)
+
+	// Test with a code without numeric identifier
+	actual = FormatCompactError(JSONError{Code: "custom", StatusCode: http.StatusInternalServerError})
+	assert.Equal(t, 0, actual.ID)
+	assert.Equal(t, "custom", actual.Code)
+}
+
+func TestServeError(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	error := &Error{Code: EINVALID, Message: "email is invalid"}
+
+	// Test without compact encoding
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	recorder := httptest.NewRecorder()
+	ServeError(recorder, r, error)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"code":"invalid","error_id":"err-1","code_id":2,"status_code":400,"message":"email is invalid"}`, recorder.Body.String())
+
+	// Test with compact encoding
+	r.Header.Set("Accept", "application/json;q=0.5, "+CompactMediaType)
+	recorder = httptest.NewRecorder()
+	ServeError(recorder, r, error)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, CompactMediaType, recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"i":2,"s":400,"m":"email is invalid"}`, recorder.Body.String())
+
+	// Test with compact encoding refused with a zero q-value
+	r.Header.Set("Accept", "application/json, "+CompactMediaType+";q=0")
+	recorder = httptest.NewRecorder()
+	ServeError(recorder, r, error)
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	r.Header.Set("Accept", CompactMediaType+"; q=0.0")
+	recorder = httptest.NewRecorder()
+	ServeError(recorder, r, error)
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+}
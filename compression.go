@@ -0,0 +1,79 @@
+package ergo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CompressionMinSize is the size of the body, in bytes, from which the error responses
+// of ServeError are compressed with the encoding accepted by the client.
+// Compression is disabled when it is negative.
+var CompressionMinSize = 1024
+
+// acceptedEncoding returns the preferred encoding of the request between gzip and deflate,
+// honoring the q-values of the Accept-Encoding header, or an empty string.
+// The * encoding applies to the encodings not listed, gzip being preferred on equal q-values.
+func acceptedEncoding(r *http.Request) string {
+	weights, wildcard := map[string]float64{}, 0.0
+	for _, accept := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params := strings.TrimSpace(accept), ""
+		if i := strings.Index(name, ";"); i >= 0 {
+			name, params = strings.TrimSpace(name[:i]), strings.TrimSpace(name[i+1:])
+		}
+		q := 1.0
+		if strings.HasPrefix(params, "q=") {
+			if parsed, err := strconv.ParseFloat(params[2:], 64); err == nil {
+				q = parsed
+			}
+		}
+		if name == "*" {
+			wildcard = q
+		} else {
+			weights[name] = q
+		}
+	}
+
+	encoding, weight := "", 0.0
+	for _, name := range []string{"gzip", "deflate"} {
+		q, ok := weights[name]
+		if !ok {
+			q = wildcard
+		}
+		if q > weight {
+			encoding, weight = name, q
+		}
+	}
+	return encoding
+}
+
+// compressBody compresses the body with the encoding accepted by the request, if it is
+// at least CompressionMinSize, and sets the Content-Encoding and Vary headers.
+// The deflate encoding is the zlib format, as defined by RFC 9110.
+// The signature of the body, if any, is computed before the compression.
+func compressBody(header http.Header, r *http.Request, body []byte) []byte {
+	if r == nil || CompressionMinSize < 0 {
+		return body
+	}
+	header.Add("Vary", "Accept-Encoding")
+	encoding := acceptedEncoding(r)
+	if encoding == "" || len(body) < CompressionMinSize {
+		return body
+	}
+
+	var buffer bytes.Buffer
+	if encoding == "gzip" {
+		writer := gzip.NewWriter(&buffer)
+		_, _ = writer.Write(body)
+		_ = writer.Close()
+	} else {
+		writer := zlib.NewWriter(&buffer)
+		_, _ = writer.Write(body)
+		_ = writer.Close()
+	}
+	header.Set("Content-Encoding", encoding)
+	return buffer.Bytes()
+}
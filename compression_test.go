@@ -0,0 +1,69 @@
+package ergo
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeErrorCompression(t *testing.T) {
+	err := &Error{Code: EINVALID, Message: strings.Repeat("Invalid request. ", 100)}
+
+	// Test with gzip
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Encoding", "deflate;q=0.5, gzip")
+	w := httptest.NewRecorder()
+	ServeError(w, r, err)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	reader, _ := gzip.NewReader(w.Body)
+	var jsonError JSONError
+	assert.NoError(t, json.NewDecoder(reader).Decode(&jsonError))
+	assert.Equal(t, err.Message, jsonError.Message)
+
+	// Test with deflate
+	r.Header.Set("Accept-Encoding", "gzip;q=0, deflate")
+	w = httptest.NewRecorder()
+	ServeError(w, r, err)
+	assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+	zlibReader, zlibErr := zlib.NewReader(w.Body)
+	assert.NoError(t, zlibErr)
+	body, _ := ioutil.ReadAll(zlibReader)
+	assert.Contains(t, string(body), `"code":"invalid"`)
+
+	// Test with a wildcard, not applying to the encodings listed
+	r.Header.Set("Accept-Encoding", "gzip;q=0, *")
+	w = httptest.NewRecorder()
+	ServeError(w, r, err)
+	assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+
+	r.Header.Set("Accept-Encoding", "*")
+	w = httptest.NewRecorder()
+	ServeError(w, r, err)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+	r.Header.Set("Accept-Encoding", "*;q=0")
+	w = httptest.NewRecorder()
+	ServeError(w, r, err)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+
+	// Test with a body smaller than CompressionMinSize
+	r.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	ServeError(w, r, &Error{Code: EINVALID})
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Body.String(), `"code":"invalid"`)
+
+	// Test without Accept-Encoding
+	r.Header.Del("Accept-Encoding")
+	w = httptest.NewRecorder()
+	ServeError(w, r, err)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
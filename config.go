@@ -0,0 +1,247 @@
+package ergo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config is the error policy of the package: the registry of the codes, the flagged
+// status codes, the Cache-Control headers, the tenant policies (messages and redaction)
+// and the stack capture rates. The severities of the codes, i.e. their log levels, are part
+// of the registry. The package variables of the same names set the policy at startup,
+// Update at runtime. The redaction is limited to the Redact flag of the tenant policies:
+// the other masking settings, e.g. GraphQLMask and CaptureSQL, are package variables that
+// are not part of the config, and the masking of the 5xx errors is not configurable.
+type Config struct {
+	Codes              map[string]CodeInfo      `json:"codes,omitempty"`
+	FlaggedStatusCodes map[string]FlaggedStatus `json:"flagged_status_codes,omitempty"`
+	CacheControl       map[string]string        `json:"cache_control,omitempty"`
+	TenantPolicies     map[string]TenantPolicy  `json:"tenant_policies,omitempty"`
+	StackRates         map[string]float64       `json:"stack_rates,omitempty"`
+}
+
+// configMu serializes the updates of the configuration
+var configMu sync.Mutex
+
+// currentConfig holds the *Config snapshot of the error policy set by Update
+var currentConfig atomic.Value
+
+// policy returns the snapshot of the error policy. The formatting and the writing of an
+// error load it once, so that they never mix the parts of two policies.
+// Until the first Update, the policy is the one of the package variables.
+func policy() *Config {
+	if config, _ := currentConfig.Load().(*Config); config != nil {
+		return config
+	}
+	return &Config{
+		Codes:              Codes,
+		FlaggedStatusCodes: FlaggedStatusCodes,
+		CacheControl:       CacheControl,
+		TenantPolicies:     TenantPolicies,
+		StackRates:         StackRates,
+	}
+}
+
+// CurrentConfig returns the current error policy of the package
+func CurrentConfig() Config {
+	return *policy()
+}
+
+// Update validates the config and replaces the error policy of the package with it, e.g. to
+// change it at runtime without a deploy. The parts of the config left nil are not replaced.
+// The policy is swapped as a whole, atomically, and an invalid config leaves it in place.
+// The package variables define the policy until the first Update, which they no longer
+// affect; read the updated policy with CurrentConfig.
+func Update(config Config) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+	next := policy().merge(config)
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	currentConfig.Store(next)
+	return nil
+}
+
+// merge returns a copy of the config with the parts of the overrides that are not nil
+func (c Config) merge(overrides Config) *Config {
+	if overrides.Codes != nil {
+		c.Codes = overrides.Codes
+	}
+	if overrides.FlaggedStatusCodes != nil {
+		c.FlaggedStatusCodes = overrides.FlaggedStatusCodes
+	}
+	if overrides.CacheControl != nil {
+		c.CacheControl = overrides.CacheControl
+	}
+	if overrides.TenantPolicies != nil {
+		c.TenantPolicies = overrides.TenantPolicies
+	}
+	if overrides.StackRates != nil {
+		c.StackRates = overrides.StackRates
+	}
+	return &c
+}
+
+// contextPolicy returns the policy for the context: the policy of the package, with the
+// config of the template of the context, if any
+func contextPolicy(ctx context.Context) *Config {
+	p := policy()
+	if template, ok := ctx.Value(templateKey{}).(Template); ok && template.Config != nil {
+		return p.merge(*template.Config)
+	}
+	return p
+}
+
+// LoadConfig reads the JSON config of the file
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// WatchConfig checks the JSON config of the file every interval, until the context is done,
+// and updates the error policy when the file is modified. The errors loading the file are
+// sent to OnDiagnostic and leave the current policy in place, as do invalid configs.
+// A non-positive interval is sent to OnDiagnostic, and the file is not watched.
+func WatchConfig(ctx context.Context, path string, interval time.Duration) {
+	if interval <= 0 {
+		OnDiagnostic(&Error{Code: EINTERNAL, Op: "ergo.WatchConfig", Err: fmt.Errorf("interval %s is not positive", interval)})
+		return
+	}
+	var modified time.Time
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if info, err := os.Stat(path); err != nil {
+			OnDiagnostic(&Error{Code: EINTERNAL, Op: "ergo.WatchConfig", Err: err})
+		} else if !info.ModTime().Equal(modified) {
+			modified = info.ModTime()
+			if config, err := LoadConfig(path); err != nil {
+				OnDiagnostic(&Error{Code: EINTERNAL, Op: "ergo.WatchConfig", Err: err})
+			} else if err := Update(config); err != nil {
+				OnDiagnostic(&Error{Code: EINTERNAL, Op: "ergo.WatchConfig", Err: err})
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Validate reports the problems of the config, e.g. at startup: codes sharing an
+// identifier, invalid statuses, codes without message, codes unknown to the registry
+// referenced by the other parts of the config, entries matching nothing or everything
+// (empty codes, tenants and flags), missing translations, i.e. tenants with a locale
+// without a message for every registered code, and rates outside 0..1.
+// The parts left nil are checked against the current policy.
+func (c Config) Validate() error {
+	codes := c.Codes
+	if codes == nil {
+		codes = policy().Codes
+	}
+
+	var problems []string
+	if _, ok := codes[EINTERNAL]; !ok {
+		problems = append(problems, "code internal is not registered")
+	}
+	ids := map[int]string{}
+	for _, code := range sortedKeys(codes) {
+		info := codes[code]
+		if code == "" {
+			problems = append(problems, "the registry has an empty code")
+		}
+		if other, ok := ids[info.ID]; ok && info.ID != 0 {
+			problems = append(problems, fmt.Sprintf("codes %s and %s have the same id %d", other, code, info.ID))
+		}
+		ids[info.ID] = code
+		if info.Status < 400 || info.Status > 599 {
+			problems = append(problems, fmt.Sprintf("code %s has the invalid status %d", code, info.Status))
+		}
+		if info.Message == "" {
+			problems = append(problems, fmt.Sprintf("code %s has no message", code))
+		}
+	}
+
+	unknown := func(part string, code string) {
+		if _, ok := codes[code]; !ok {
+			problems = append(problems, fmt.Sprintf("%s references the unknown code %s", part, code))
+		}
+	}
+	for _, code := range sortedKeys(c.FlaggedStatusCodes) {
+		unknown("flagged_status_codes", code)
+		flagged := c.FlaggedStatusCodes[code]
+		if flagged.Flag == "" {
+			problems = append(problems, fmt.Sprintf("flagged status of code %s has no flag", code))
+		}
+		if flagged.Status < 400 || flagged.Status > 599 {
+			problems = append(problems, fmt.Sprintf("flagged status of code %s has the invalid status %d", code, flagged.Status))
+		}
+	}
+	for _, code := range sortedKeys(c.CacheControl) {
+		unknown("cache_control", code)
+		if strings.ContainsAny(c.CacheControl[code], "\r\n") {
+			problems = append(problems, fmt.Sprintf("cache_control of code %s is not a valid header value", code))
+		}
+	}
+	for _, tenant := range sortedKeys(c.TenantPolicies) {
+		tenantPolicy := c.TenantPolicies[tenant]
+		if tenant == "" {
+			// The empty tenant matches every request without tenant
+			problems = append(problems, "tenant_policies has a policy for the empty tenant")
+		}
+		for _, code := range sortedKeys(tenantPolicy.Messages) {
+			unknown("tenant_policies."+tenant, code)
+			if tenantPolicy.Messages[code] == "" {
+				problems = append(problems, fmt.Sprintf("tenant_policies.%s has an empty message for code %s", tenant, code))
+			}
+		}
+		if tenantPolicy.Locale == "" {
+			continue
+		}
+		for _, code := range sortedKeys(codes) {
+			if _, ok := tenantPolicy.Messages[code]; !ok {
+				problems = append(problems, fmt.Sprintf("tenant_policies.%s has no %s translation for code %s", tenant, tenantPolicy.Locale, code))
+			}
+		}
+	}
+	for _, code := range sortedKeys(c.StackRates) {
+		unknown("stack_rates", code)
+		// The comparisons are false for NaN
+		if rate := c.StackRates[code]; !(rate >= 0 && rate <= 1) {
+			problems = append(problems, fmt.Sprintf("stack rate of code %s is outside 0..1", code))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("ergo: invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// sortedKeys returns the sorted keys of a map with string keys
+func sortedKeys(m interface{}) []string {
+	value := reflect.ValueOf(m)
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,184 @@
+package ergo
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// resetConfig restores the policy of the package variables
+func resetConfig() {
+	currentConfig.Store((*Config)(nil))
+}
+
+func TestUpdate(t *testing.T) {
+	defer resetConfig()
+
+	codes := Mapping()
+	codes[EINVALID] = CodeInfo{ID: 2, Status: 422, Message: "Invalid request."}
+	assert.NoError(t, Update(Config{Codes: codes, CacheControl: map[string]string{ENOTFOUND: "max-age=60"}}))
+	assert.Equal(t, 422, ErrorStatusCode(&Error{Code: EINVALID}))
+	assert.Equal(t, "max-age=60", ErrorCacheControl(&Error{Code: ENOTFOUND}))
+	assert.Equal(t, StackRates, CurrentConfig().StackRates)
+
+	// Test with an invalid config, which leaves the policy in place
+	err := Update(Config{CacheControl: map[string]string{"not_foud": "max-age=60"}})
+	assert.EqualError(t, err, "ergo: invalid config: cache_control references the unknown code not_foud")
+	assert.Equal(t, "max-age=60", ErrorCacheControl(&Error{Code: ENOTFOUND}))
+}
+
+func TestUpdateConcurrent(t *testing.T) {
+	defer resetConfig()
+
+	// Test with formats racing with updates, run with -race
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = Update(Config{CacheControl: map[string]string{ENOTFOUND: "max-age=60"}})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			WriteError(httptest.NewRecorder(), &Error{Code: ENOTFOUND})
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, "max-age=60", CurrentConfig().CacheControl[ENOTFOUND])
+}
+
+func TestWatchConfig(t *testing.T) {
+	defer resetConfig()
+	var mu sync.Mutex
+	var diagnostics []error
+	defer func() { OnDiagnostic = func(diagnostic error) {} }()
+	OnDiagnostic = func(diagnostic error) {
+		mu.Lock()
+		defer mu.Unlock()
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	dir, _ := ioutil.TempDir("", "ergo")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "errors.json")
+	_ = ioutil.WriteFile(path, []byte(`{"cache_control":{"not_found":"max-age=60"}}`), 0600)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		WatchConfig(ctx, path, time.Millisecond)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		return CurrentConfig().CacheControl[ENOTFOUND] == "max-age=60"
+	}, time.Second, time.Millisecond)
+
+	// Test with an invalid file
+	_ = ioutil.WriteFile(path, []byte(`{`), 0600)
+	_ = os.Chtimes(path, time.Now(), time.Now().Add(time.Hour))
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(diagnostics) > 0
+	}, time.Second, time.Millisecond)
+	cancel()
+	<-done
+	assert.Equal(t, "max-age=60", CurrentConfig().CacheControl[ENOTFOUND])
+
+	// Test with an invalid interval
+	diagnostics = nil
+	WatchConfig(context.Background(), path, 0)
+	assert.Len(t, diagnostics, 1)
+	assert.EqualError(t, diagnostics[0], "ergo.WatchConfig: interval 0s is not positive")
+}
+
+func TestLoadConfigTenants(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "ergo")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "errors.json")
+	_ = ioutil.WriteFile(path, []byte(`{
+		"flagged_status_codes": {"not_found": {"flag": "gone", "status": 410}},
+		"tenant_policies": {"acme": {"messages": {"not_found": "Nothing here."}, "redact": true, "docs_url": "https://docs.acme.com"}}
+	}`), 0600)
+
+	config, err := LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, FlaggedStatus{Flag: "gone", Status: 410}, config.FlaggedStatusCodes[ENOTFOUND])
+	assert.Equal(t, TenantPolicy{
+		Messages: map[string]string{ENOTFOUND: "Nothing here."},
+		Redact:   true,
+		DocsURL:  "https://docs.acme.com",
+	}, config.TenantPolicies["acme"])
+}
+
+func TestConfigValidate(t *testing.T) {
+	// Test with the current policy
+	assert.NoError(t, CurrentConfig().Validate())
+
+	codes := Mapping()
+	codes["teapot"] = CodeInfo{ID: 2, Status: 418}
+	codes["redirect"] = CodeInfo{Status: 302, Message: "Moved."}
+	err := Config{
+		Codes:              codes,
+		FlaggedStatusCodes: map[string]FlaggedStatus{EINVALID: {Status: 422}},
+		CacheControl:       map[string]string{"not_foud": "max-age=60"},
+		TenantPolicies:     map[string]TenantPolicy{"acme": {Messages: map[string]string{"forbiden": "No."}}},
+		StackRates:         map[string]float64{EINVALID: 2},
+	}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: "+
+		"code redirect has the invalid status 302; "+
+		"codes invalid and teapot have the same id 2; "+
+		"code teapot has no message; "+
+		"flagged status of code invalid has no flag; "+
+		"cache_control references the unknown code not_foud; "+
+		"tenant_policies.acme references the unknown code forbiden; "+
+		"stack rate of code invalid is outside 0..1")
+
+	// Test with invalid matching entries
+	codes = Mapping()
+	codes[""] = CodeInfo{Status: 400, Message: "Bad request."}
+	err = Config{Codes: codes}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: the registry has an empty code")
+	err = Config{TenantPolicies: map[string]TenantPolicy{"": {Redact: true}}}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: tenant_policies has a policy for the empty tenant")
+	err = Config{CacheControl: map[string]string{ENOTFOUND: "max-age=60\r\nSet-Cookie: a=b"}}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: cache_control of code not_found is not a valid header value")
+
+	// Test with invalid flag entries
+	err = Config{FlaggedStatusCodes: map[string]FlaggedStatus{EINVALID: {Flag: "strict-validation", Status: 200}}}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: flagged status of code invalid has the invalid status 200")
+
+	// Test with invalid rate entries
+	err = Config{StackRates: map[string]float64{EINVALID: math.NaN()}}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: stack rate of code invalid is outside 0..1")
+	err = Config{StackRates: map[string]float64{EINVALID: -0.5}}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: stack rate of code invalid is outside 0..1")
+
+	// Test with missing translations
+	translated := map[string]string{}
+	for code := range Codes {
+		translated[code] = "Erreur."
+	}
+	delete(translated, ENOTFOUND)
+	translated[EINVALID] = ""
+	err = Config{TenantPolicies: map[string]TenantPolicy{"globex": {Messages: translated, Locale: "fr-FR"}}}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: "+
+		"tenant_policies.globex has an empty message for code invalid; "+
+		"tenant_policies.globex has no fr-FR translation for code not_found")
+	translated[ENOTFOUND], translated[EINVALID] = "Introuvable.", "Requête invalide."
+	assert.NoError(t, Config{TenantPolicies: map[string]TenantPolicy{"globex": {Messages: translated, Locale: "fr-FR"}}}.Validate())
+
+	// Test without internal code
+	err = Config{Codes: map[string]CodeInfo{EINVALID: Codes[EINVALID]}}.Validate()
+	assert.EqualError(t, err, "ergo: invalid config: code internal is not registered")
+}
@@ -0,0 +1,25 @@
+package ergo
+
+// Conflict returns an ECONFLICT error carrying the current server-side representation
+// of the resource under the "current" key of the details.
+func Conflict(message string, current interface{}) *Error {
+	return construct(&Error{
+		Code:    ECONFLICT,
+		Message: message,
+		Details: map[string]interface{}{
+			"current": current,
+		},
+	})
+}
+
+// ConflictETag returns an ECONFLICT error carrying the current ETag, or version,
+// of the resource under the "etag" key of the details.
+func ConflictETag(message string, etag string) *Error {
+	return construct(&Error{
+		Code:    ECONFLICT,
+		Message: message,
+		Details: map[string]interface{}{
+			"etag": etag,
+		},
+	})
+}
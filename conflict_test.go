@@ -0,0 +1,27 @@
+package ergo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflict(t *testing.T) {
+	current := map[string]interface{}{"id": "inv_42", "version": 3}
+	err := Conflict("invoice was modified", current)
+	assert.Equal(t, ECONFLICT, ErrorCode(err))
+	assert.Equal(t, http.StatusConflict, ErrorStatusCode(err))
+	assert.Equal(t, "invoice was modified", ErrorMessage(err))
+	assert.Equal(t, current, ErrorDetails(err)["current"])
+
+	// Test without message
+	err = Conflict("", current)
+	assert.Equal(t, "Conflict error.", ErrorMessage(err))
+}
+
+func TestConflictETag(t *testing.T) {
+	err := ConflictETag("invoice was modified", `"v3"`)
+	assert.Equal(t, ECONFLICT, ErrorCode(err))
+	assert.Equal(t, map[string]interface{}{"etag": `"v3"`}, ErrorDetails(err))
+}
@@ -0,0 +1,48 @@
+package ergo
+
+import "context"
+
+// ContextFields maps the keys of the fields to the functions extracting their value
+// from the context, used by NewCtx. By default the values are the ones set with WithField;
+// replace them to read the values set by other middleware.
+var ContextFields = map[string]func(ctx context.Context) string{
+	FieldRequestID: contextField(FieldRequestID),
+	FieldTraceID:   contextField(FieldTraceID),
+	FieldUserID:    contextField(FieldUserID),
+	FieldLocale:    contextField(FieldLocale),
+}
+
+type fieldsKey struct{}
+
+// WithField returns a copy of the context carrying the value of a key of the fields
+func WithField(ctx context.Context, key string, value string) context.Context {
+	parent, _ := ctx.Value(fieldsKey{}).(map[string]string)
+	fields := make(map[string]string, len(parent)+1)
+	for k, v := range parent {
+		fields[k] = v
+	}
+	fields[key] = value
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// NewCtx returns an error with the code and message, whose fields are enriched with
+// the ContextFields of the context. The fields are internal, so the identifiers of the
+// user and of the request are never sent to the client. The template of the context,
+// if any, is applied.
+func NewCtx(ctx context.Context, code string, message string) *Error {
+	err := construct(&Error{Code: code, Message: message})
+	for key, extract := range ContextFields {
+		if value := extract(ctx); value != "" {
+			err.setField(key, value)
+		}
+	}
+	return ApplyTemplate(ctx, err)
+}
+
+// contextField returns a function extracting the value set with WithField
+func contextField(key string) func(ctx context.Context) string {
+	return func(ctx context.Context) string {
+		fields, _ := ctx.Value(fieldsKey{}).(map[string]string)
+		return fields[key]
+	}
+}
@@ -0,0 +1,42 @@
+package ergo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type requestIDKey struct{}
+
+func TestNewCtx(t *testing.T) {
+	// Test without fields in the context
+	err := NewCtx(context.Background(), ENOTFOUND, "user not found")
+	assert.Equal(t, &Error{Code: ENOTFOUND, Message: "user not found"}, err)
+
+	ctx := WithField(context.Background(), FieldRequestID, "req_1")
+	ctx = WithField(ctx, FieldUserID, "usr_7")
+	ctx = WithField(ctx, FieldLocale, "it-IT")
+	ctx = WithTemplate(ctx, Template{OpPrefix: "users"})
+	err = NewCtx(ctx, ENOTFOUND, "user not found")
+	assert.Equal(t, map[string]interface{}{
+		FieldRequestID: "req_1",
+		FieldUserID:    "usr_7",
+		FieldLocale:    "it-IT",
+	}, err.Fields)
+	assert.Empty(t, err.Details)
+	assert.Empty(t, FormatError(err).Details)
+	assert.Equal(t, "users", err.Op)
+}
+
+func TestContextFields(t *testing.T) {
+	defer func(extract func(ctx context.Context) string) { ContextFields[FieldRequestID] = extract }(ContextFields[FieldRequestID])
+	ContextFields[FieldRequestID] = func(ctx context.Context) string {
+		requestID, _ := ctx.Value(requestIDKey{}).(string)
+		return requestID
+	}
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req_2")
+	err := NewCtx(ctx, EINVALID, "")
+	assert.Equal(t, "req_2", err.Fields[FieldRequestID])
+}
@@ -0,0 +1,19 @@
+package ergo
+
+// Decorator modifies the errors constructed by the package,
+// e.g. to attach the service name, version or region to the details.
+type Decorator func(e *Error)
+
+// Decorators are run, in order, on every error constructed by the package
+var Decorators []Decorator
+
+// construct validates the operation and the code of the new error, captures its stack and runs the Decorators on it
+func construct(e *Error) *Error {
+	checkOp(e.Op)
+	checkCode(e.Code)
+	e.CaptureStack()
+	for _, decorate := range Decorators {
+		decorate(e)
+	}
+	return e
+}
@@ -0,0 +1,31 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecorators(t *testing.T) {
+	defer func() { Decorators = nil }()
+	Decorators = []Decorator{
+		func(e *Error) {
+			if e.Details == nil {
+				e.Details = make(map[string]interface{})
+			}
+			e.Details["service"] = "billing"
+		},
+		func(e *Error) {
+			e.Details["region"] = "eu-west-1"
+		},
+	}
+
+	err := InvalidPageSize(500, 100)
+	assert.Equal(t, "billing", err.Details["service"])
+	assert.Equal(t, "eu-west-1", err.Details["region"])
+	assert.Equal(t, 100, err.Details["max_page_size"])
+
+	traced := Trace(errors.New("some error"))
+	assert.Equal(t, "billing", ErrorDetails(traced)["service"])
+}
@@ -0,0 +1,60 @@
+package ergo
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// fieldDeprecation is the field carrying the deprecation of an error
+const fieldDeprecation = "deprecation"
+
+// Deprecation defines the deprecation of an endpoint or a parameter
+// At is the time of the deprecation, sent as the Deprecation header (RFC 9745)
+// Sunset is the time the endpoint stops working, sent as the Sunset header (RFC 8594), if not zero
+// Link is the documentation of the deprecation, sent as a Link header, if not empty
+type Deprecation struct {
+	At     time.Time
+	Sunset time.Time
+	Link   string
+}
+
+// SetDeprecation marks the error as stemming from a deprecated endpoint or parameter,
+// so that the writer sends the deprecation headers with the error response
+func (err *Error) SetDeprecation(deprecation Deprecation) *Error {
+	err = err.own()
+	if err.Fields == nil {
+		err.Fields = make(map[string]interface{})
+	}
+	err.Fields[fieldDeprecation] = deprecation
+	return err
+}
+
+// ErrorDeprecation returns the deprecation of the error, if available
+func ErrorDeprecation(err error) (Deprecation, bool) {
+	deprecation, ok := ErrorFields(err)[fieldDeprecation].(Deprecation)
+	return deprecation, ok
+}
+
+// Deprecate sets the deprecation headers on a successful response, e.g. one with warnings
+func Deprecate(w http.ResponseWriter, deprecation Deprecation) {
+	setDeprecationHeaders(w.Header(), deprecation)
+}
+
+// setErrorDeprecationHeaders sets the deprecation headers of the error, if deprecated
+func setErrorDeprecationHeaders(header http.Header, err error) {
+	if deprecation, ok := ErrorDeprecation(err); ok {
+		setDeprecationHeaders(header, deprecation)
+	}
+}
+
+// setDeprecationHeaders sets the Deprecation, Sunset and Link headers of the deprecation
+func setDeprecationHeaders(header http.Header, deprecation Deprecation) {
+	header.Set("Deprecation", "@"+strconv.FormatInt(deprecation.At.Unix(), 10))
+	if !deprecation.Sunset.IsZero() {
+		header.Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if deprecation.Link != "" {
+		header.Add("Link", "<"+deprecation.Link+`>; rel="deprecation"`)
+	}
+}
@@ -0,0 +1,38 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecation(t *testing.T) {
+	deprecation := Deprecation{
+		At:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		Link:   "https://docs.example.com/deprecations/v1-users",
+	}
+
+	// Test with a deprecated error
+	err := (&Error{Code: EINVALID}).SetDeprecation(deprecation)
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, &Error{Op: "v1.users.Create", Err: err})
+	assert.Equal(t, "@1704067200", recorder.Header().Get("Deprecation"))
+	assert.Equal(t, "Mon, 01 Jul 2024 00:00:00 GMT", recorder.Header().Get("Sunset"))
+	assert.Equal(t, `<https://docs.example.com/deprecations/v1-users>; rel="deprecation"`, recorder.Header().Get("Link"))
+	assert.NotContains(t, recorder.Body.String(), "deprecation")
+
+	// Test with an error not deprecated
+	recorder = httptest.NewRecorder()
+	WriteError(recorder, &Error{Code: EINVALID})
+	assert.Empty(t, recorder.Header().Get("Deprecation"))
+
+	// Test with a successful response
+	recorder = httptest.NewRecorder()
+	Deprecate(recorder, Deprecation{At: deprecation.At})
+	assert.Equal(t, "@1704067200", recorder.Header().Get("Deprecation"))
+	assert.Empty(t, recorder.Header().Get("Sunset"))
+	assert.Empty(t, recorder.Header().Get("Link"))
+}
@@ -0,0 +1,159 @@
+package ergo
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ReporterTimeout is the time the reporters, run concurrently, have to report an error
+// before a diagnostic is emitted for each one still running. A reporter that timed out is
+// skipped until its report returns, and the count of the skipped reports is then emitted
+// as a diagnostic. Reporters run sequentially in the caller's goroutine when it is 0.
+var ReporterTimeout = time.Second
+
+// OnDiagnostic receives the internal diagnostics of the package, e.g. a reporter that
+// panicked or timed out, as errors carrying the reporter and the stack in the fields.
+// By default diagnostics are discarded.
+var OnDiagnostic = func(diagnostic error) {}
+
+var (
+	stuckMu sync.Mutex
+	// stuckReporters maps the reporters whose report timed out and is still running
+	// to the count of the reports they have skipped since
+	stuckReporters = map[Reporter]int{}
+)
+
+// safeReport sends the error to the reporters, converting panics and timeouts into diagnostics
+func safeReport(reporters []Reporter, err error) {
+	if ReporterTimeout <= 0 {
+		for _, reporter := range reporters {
+			runReporter(reporter, err)
+		}
+		return
+	}
+
+	type running struct {
+		reporter Reporter
+		done     chan struct{}
+	}
+	var started []running
+	for _, reporter := range reporters {
+		if skipStuck(reporter) {
+			continue
+		}
+		done := make(chan struct{})
+		go func(reporter Reporter) {
+			runReporter(reporter, err)
+			finishReport(reporter, done)
+		}(reporter)
+		started = append(started, running{reporter: reporter, done: done})
+	}
+
+	timer := time.NewTimer(ReporterTimeout)
+	defer timer.Stop()
+	expired := false
+	for _, r := range started {
+		if !expired {
+			select {
+			case <-r.done:
+				continue
+			case <-timer.C:
+				expired = true
+			}
+		}
+		if markStuck(r.reporter, r.done) {
+			OnDiagnostic(&Error{
+				Code:    EINTERNAL,
+				Op:      "ergo.report",
+				Message: fmt.Sprintf("Reporter %T timed out after %s.", r.reporter, ReporterTimeout),
+				Fields: map[string]interface{}{
+					"reporter": fmt.Sprintf("%T", r.reporter),
+					"timeout":  ReporterTimeout.String(),
+				},
+				Err: err,
+			})
+		}
+	}
+}
+
+// trackable reports whether the reporter can be tracked as stuck, i.e. is a valid map key
+func trackable(reporter Reporter) bool {
+	return reflect.TypeOf(reporter).Comparable()
+}
+
+// skipStuck reports whether the reporter is stuck, counting the skipped report
+func skipStuck(reporter Reporter) bool {
+	if !trackable(reporter) {
+		return false
+	}
+	stuckMu.Lock()
+	defer stuckMu.Unlock()
+	skipped, stuck := stuckReporters[reporter]
+	if stuck {
+		stuckReporters[reporter] = skipped + 1
+	}
+	return stuck
+}
+
+// markStuck marks the reporter as stuck if its report is still running
+func markStuck(reporter Reporter, done chan struct{}) bool {
+	stuckMu.Lock()
+	defer stuckMu.Unlock()
+	select {
+	case <-done:
+		return false
+	default:
+	}
+	if trackable(reporter) {
+		stuckReporters[reporter] = 0
+	}
+	return true
+}
+
+// finishReport marks the report as done and the reporter as no longer stuck,
+// emitting the count of the reports it has skipped
+func finishReport(reporter Reporter, done chan struct{}) {
+	stuckMu.Lock()
+	close(done)
+	skipped, stuck := 0, false
+	if trackable(reporter) {
+		skipped, stuck = stuckReporters[reporter]
+		delete(stuckReporters, reporter)
+	}
+	stuckMu.Unlock()
+
+	if stuck && skipped > 0 {
+		OnDiagnostic(&Error{
+			Code:    EINTERNAL,
+			Op:      "ergo.report",
+			Message: fmt.Sprintf("Reporter %T skipped %d reports while timed out.", reporter, skipped),
+			Fields: map[string]interface{}{
+				"reporter": fmt.Sprintf("%T", reporter),
+				"skipped":  skipped,
+			},
+		})
+	}
+}
+
+// runReporter sends the error to the reporter, converting panics into diagnostics
+func runReporter(reporter Reporter, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			OnDiagnostic(&Error{
+				Code:    EINTERNAL,
+				Op:      "ergo.report",
+				Message: fmt.Sprintf("Reporter %T panicked: %v", reporter, recovered),
+				Fields: map[string]interface{}{
+					"reporter": fmt.Sprintf("%T", reporter),
+					"panic":    fmt.Sprint(recovered),
+					"stack":    string(debug.Stack()),
+				},
+				Err: err,
+			})
+		}
+	}()
+	reporter.Report(err)
+}
@@ -0,0 +1,121 @@
+package ergo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type panicReporter struct{}
+
+func (panicReporter) Report(err error) {
+	panic("reporter bug")
+}
+
+type slowReporter struct {
+	release chan struct{}
+}
+
+func (r slowReporter) Report(err error) {
+	<-r.release
+}
+
+func TestSafeReport(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	var mu sync.Mutex
+	var diagnostics []error
+	defer func() {
+		OnDiagnostic = func(diagnostic error) {}
+		ReporterTimeout = time.Second
+		Reporters = nil
+	}()
+	OnDiagnostic = func(diagnostic error) {
+		mu.Lock()
+		defer mu.Unlock()
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	ReporterTimeout = 10 * time.Millisecond
+	slow := slowReporter{release: make(chan struct{})}
+	defer close(slow.release)
+	capture := &captureReporter{}
+	Reporters = []Reporter{panicReporter{}, slow, capture}
+
+	err := &Error{Code: EINVALID}
+	assert.NotPanics(t, func() { HandleError(err) })
+	assert.Equal(t, []error{&Error{Err: err, ErrorID: "err-1"}}, capture.reported())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, diagnostics, 2)
+	fields := ErrorFields(diagnostics[0])
+	assert.Equal(t, "ergo.panicReporter", fields["reporter"])
+	assert.Equal(t, "reporter bug", fields["panic"])
+	assert.Contains(t, fields["stack"], "panicReporter")
+	assert.Equal(t, "Reporter ergo.slowReporter timed out after 10ms.", ErrorMessage(diagnostics[1]))
+}
+
+func TestSafeReportStuck(t *testing.T) {
+	var mu sync.Mutex
+	var diagnostics []error
+	defer func() {
+		OnDiagnostic = func(diagnostic error) {}
+		ReporterTimeout = time.Second
+	}()
+	OnDiagnostic = func(diagnostic error) {
+		mu.Lock()
+		defer mu.Unlock()
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	ReporterTimeout = 100 * time.Millisecond
+	slow := []slowReporter{{release: make(chan struct{})}, {release: make(chan struct{})}, {release: make(chan struct{})}}
+	reporters := []Reporter{slow[0], slow[1], slow[2]}
+
+	// Test that the reporters share the deadline
+	start := time.Now()
+	safeReport(reporters, &Error{Code: EINVALID})
+	assert.Less(t, int64(time.Since(start)), int64(250*time.Millisecond))
+
+	// Test that the stuck reporters are skipped
+	start = time.Now()
+	safeReport(reporters, &Error{Code: EINVALID})
+	safeReport(reporters[:1], &Error{Code: EINVALID})
+	assert.Less(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	mu.Lock()
+	assert.Len(t, diagnostics, 3)
+	mu.Unlock()
+
+	// Test with the skipped reports counted when the reporter returns
+	for _, reporter := range slow {
+		close(reporter.release)
+	}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(diagnostics) == 6
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	var messages []string
+	for _, diagnostic := range diagnostics[3:] {
+		messages = append(messages, ErrorMessage(diagnostic))
+	}
+	assert.ElementsMatch(t, []string{
+		"Reporter ergo.slowReporter skipped 2 reports while timed out.",
+		"Reporter ergo.slowReporter skipped 1 reports while timed out.",
+		"Reporter ergo.slowReporter skipped 1 reports while timed out.",
+	}, messages)
+
+	// Test that the reporters are no longer skipped
+	for _, reporter := range reporters {
+		assert.False(t, skipStuck(reporter))
+	}
+}
+
+func TestSafeReportWithoutTimeout(t *testing.T) {
+	defer func() { ReporterTimeout = time.Second }()
+	ReporterTimeout = 0
+
+	assert.NotPanics(t, func() { safeReport([]Reporter{panicReporter{}}, &Error{Code: EINVALID}) })
+}
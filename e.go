@@ -0,0 +1,36 @@
+package ergo
+
+import "fmt"
+
+// Message is a human-readable message, to distinguish it from an operation in the arguments of E
+type Message string
+
+// E returns an error built from the arguments, in any order, like upspin.io/errors.
+// A Code sets the code, a Message the message, an error the wrapped error and a
+// map[string]interface{} the details. A string sets the code if it is present in the
+// registry and the error has no code yet, the operation otherwise.
+// Arguments of other types are ignored and emitted as a diagnostic.
+func E(args ...interface{}) *Error {
+	e := &Error{}
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case Code:
+			e.Code = string(arg)
+		case Message:
+			e.Message = string(arg)
+		case string:
+			if _, ok := policy().Codes[arg]; ok && e.Code == "" {
+				e.Code = arg
+			} else {
+				e.Op = arg
+			}
+		case error:
+			e.Err = arg
+		case map[string]interface{}:
+			e.Details = arg
+		default:
+			OnDiagnostic(&Error{Code: EINTERNAL, Op: "ergo.E", Message: fmt.Sprintf("Unknown argument of type %T.", arg)})
+		}
+	}
+	return construct(e)
+}
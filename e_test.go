@@ -0,0 +1,40 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestE(t *testing.T) {
+	cause := errors.New("duplicate key")
+
+	// Test with the arguments in any order
+	err := E(cause, "user.Create", Message("Email already used."), ECONFLICT, map[string]interface{}{"field": "email"})
+	assert.Equal(t, &Error{
+		Code:    ECONFLICT,
+		Message: "Email already used.",
+		Op:      "user.Create",
+		Err:     cause,
+		Details: map[string]interface{}{"field": "email"},
+	}, err)
+
+	// Test with a typed code and an operation equal to a code
+	err = E(Code("teapot"), "invalid")
+	assert.Equal(t, "teapot", err.Code)
+	assert.Equal(t, "invalid", err.Op)
+
+	// Test with an unknown argument
+	defer func(onDiagnostic func(error)) {
+		OnDiagnostic = onDiagnostic
+	}(OnDiagnostic)
+	var diagnostics []error
+	OnDiagnostic = func(diagnostic error) {
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	err = E(EINVALID, 42)
+	assert.Equal(t, EINVALID, err.Code)
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, "Unknown argument of type int.", ErrorMessage(diagnostics[0]))
+}
@@ -0,0 +1,76 @@
+package ergo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// EncryptionKey is the shared AES key (16, 24 or 32 bytes) used to encrypt the internal
+// details of the errors written by WriteError. Internal details are omitted when it is empty.
+var EncryptionKey []byte
+
+// InternalDetails defines the details of the error not meant to be read by the client
+// Op is the logical operation that has generated the error
+// Error is the string representation of the error stack
+// Fields are the fields of the error stack
+type InternalDetails struct {
+	Op     string                 `json:"op,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// EncryptInternal returns the internal details of the error encrypted with AES-GCM
+// and encoded in base64
+func EncryptInternal(key []byte, err error) (string, error) {
+	plaintext, _ := json.Marshal(InternalDetails{
+		Op:     errorOp(err),
+		Error:  err.Error(),
+		Fields: ErrorFields(err),
+	})
+	gcm, gcmErr := newGCM(key)
+	if gcmErr != nil {
+		return "", gcmErr
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, randErr := io.ReadFull(rand.Reader, nonce); randErr != nil {
+		return "", randErr
+	}
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptInternal returns the internal details encrypted by EncryptInternal
+func DecryptInternal(key []byte, encrypted string) (InternalDetails, error) {
+	var details InternalDetails
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return details, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return details, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return details, errors.New("ergo: encrypted internal details too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return details, err
+	}
+	err = json.Unmarshal(plaintext, &details)
+	return details, err
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
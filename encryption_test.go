@@ -0,0 +1,49 @@
+package ergo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptInternal(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	error := &Error{
+		Op:  "user.Get",
+		Err: errors.New("connection refused"),
+	}
+
+	encrypted, err := EncryptInternal(key, error)
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, "connection refused")
+
+	details, err := DecryptInternal(key, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, InternalDetails{Op: "user.Get", Error: "user.Get: connection refused"}, details)
+
+	// Test with the wrong key
+	_, err = DecryptInternal([]byte("fedcba9876543210fedcba9876543210"), encrypted)
+	assert.Error(t, err)
+
+	// Test with an invalid key
+	_, err = EncryptInternal([]byte("short"), error)
+	assert.Error(t, err)
+}
+
+func TestFormatErrorContextInternal(t *testing.T) {
+	error := &Error{Code: EINVALID, Err: errors.New("bad json")}
+
+	// Test without EncryptionKey
+	actual := FormatErrorContext(context.Background(), error)
+	assert.Empty(t, actual.Internal)
+
+	// Test with EncryptionKey
+	defer func() { EncryptionKey = nil }()
+	EncryptionKey = []byte("0123456789abcdef")
+	actual = FormatErrorContext(context.Background(), error)
+	details, err := DecryptInternal(EncryptionKey, actual.Internal)
+	assert.NoError(t, err)
+	assert.Equal(t, "bad json", details.Error)
+}
@@ -0,0 +1,72 @@
+// Package envoy converts ergo errors into the denied responses of the Envoy ext_authz filter.
+//
+// DeniedResponse carries the values of the CheckResponse of the gRPC authorization service
+// without depending on go-control-plane: Code is the code of its Status, and HTTPStatus,
+// Headers and Body are the ones of its DeniedHttpResponse.
+package envoy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/skullflow/ergo"
+	"github.com/skullflow/ergo/internal/recorder"
+)
+
+// gRPC status codes of the denied responses
+const (
+	CodeInvalidArgument    int32 = 3
+	CodeNotFound           int32 = 5
+	CodePermissionDenied   int32 = 7
+	CodeResourceExhausted  int32 = 8
+	CodeFailedPrecondition int32 = 9
+	CodeAborted            int32 = 10
+	CodeInternal           int32 = 13
+	CodeUnavailable        int32 = 14
+	CodeUnauthenticated    int32 = 16
+)
+
+// DeniedResponse is the denial of a request by the authorization service
+type DeniedResponse struct {
+	Code       int32
+	HTTPStatus int
+	Headers    map[string]string
+	Body       string
+}
+
+// FormatDeniedResponse returns the denied response of the error, whose body and headers
+// are written with ergo.WriteErrorContext, and reports the error.
+func FormatDeniedResponse(ctx context.Context, err error) DeniedResponse {
+	w := recorder.New()
+	ergo.WriteErrorContext(ctx, w, err)
+	return DeniedResponse{
+		Code:       statusCode(w.Status),
+		HTTPStatus: w.Status,
+		Headers:    w.Headers(),
+		Body:       string(w.Body),
+	}
+}
+
+// statusCode returns the gRPC status code of the HTTP status of the denial
+func statusCode(status int) int32 {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidArgument
+	case http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeAborted
+	case http.StatusPreconditionFailed:
+		return CodeFailedPrecondition
+	case http.StatusTooManyRequests:
+		return CodeResourceExhausted
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodePermissionDenied
+}
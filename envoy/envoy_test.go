@@ -0,0 +1,26 @@
+package envoy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skullflow/ergo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDeniedResponse(t *testing.T) {
+	defer func(generator ergo.IDGenerator) { ergo.DefaultIDGenerator = generator }(ergo.DefaultIDGenerator)
+	ergo.DefaultIDGenerator = ergo.IDGeneratorFunc(func() string { return "err-1" })
+
+	response := FormatDeniedResponse(context.Background(), &ergo.Error{Code: ergo.EFORBIDDEN, Message: "Policy denied."})
+	assert.Equal(t, CodePermissionDenied, response.Code)
+	assert.Equal(t, 403, response.HTTPStatus)
+	assert.Equal(t, "application/json; charset=utf-8", response.Headers["Content-Type"])
+	assert.JSONEq(t, `{"code":"forbidden","error_id":"err-1","code_id":6,"status_code":403,"message":"Policy denied."}`, response.Body)
+
+	// Test with other statuses
+	assert.Equal(t, CodeUnauthenticated, FormatDeniedResponse(context.Background(), &ergo.Error{Code: ergo.EUNAUTHORIZED}).Code)
+	assert.Equal(t, CodeResourceExhausted, FormatDeniedResponse(context.Background(), &ergo.Error{Code: ergo.ETOOMANYREQUESTS}).Code)
+	assert.Equal(t, CodeInternal, FormatDeniedResponse(context.Background(), errors.New("some error")).Code)
+}
@@ -0,0 +1,50 @@
+package ergotest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FakeClock is an ergo.Clock returning a fixed time, moved forward with Advance.
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock at the time
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// SequenceIDs is an ergo.IDGenerator returning the prefix followed by a counter, e.g. "id-1".
+// It is safe for concurrent use.
+type SequenceIDs struct {
+	Prefix string
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewID returns the next identifier of the sequence
+func (s *SequenceIDs) NewID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	return fmt.Sprintf("%s%d", s.Prefix, s.next)
+}
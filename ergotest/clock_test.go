@@ -0,0 +1,24 @@
+package ergotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skullflow/ergo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var clock ergo.Clock = NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.(*FakeClock).Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+}
+
+func TestSequenceIDs(t *testing.T) {
+	var ids ergo.IDGenerator = &SequenceIDs{Prefix: "id-"}
+	assert.Equal(t, "id-1", ids.NewID())
+	assert.Equal(t, "id-2", ids.NewID())
+}
@@ -0,0 +1,71 @@
+// Package ergotest provides utilities for testing the errors served with ergo.
+package ergotest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skullflow/ergo"
+)
+
+// UpdateEnv is the environment variable that, when set to 1, makes Conformance
+// write the golden files instead of comparing them.
+const UpdateEnv = "ERGOTEST_UPDATE"
+
+// Case defines an error shape checked by Conformance
+type Case struct {
+	Name string
+	Err  error
+}
+
+// Cases is the matrix of error shapes checked by Conformance
+var Cases = []Case{
+	{Name: "plain", Err: errors.New("some error")},
+	{Name: "code", Err: &ergo.Error{Code: ergo.ENOTFOUND}},
+	{Name: "message", Err: &ergo.Error{Code: ergo.EINVALID, Message: "email is invalid"}},
+	{Name: "details", Err: &ergo.Error{Code: ergo.ECONFLICT, Details: map[string]interface{}{"version": 3}}},
+	{Name: "wrapped", Err: &ergo.Error{Op: "user.Get", Err: &ergo.Error{Code: ergo.EFORBIDDEN}}},
+	{Name: "internal", Err: &ergo.Error{Code: ergo.EINTERNAL, Op: "db.Query", Err: errors.New("connection refused")}},
+	{Name: "unregistered", Err: &ergo.Error{Code: "unregistered"}},
+}
+
+// Conformance serves every error of Cases with serve and compares the status code,
+// the Content-Type and the body of the response with the golden files in dir.
+// The configuration of serve must produce deterministic responses: the error_id of each case
+// is generated by a SequenceIDs with the "err-" prefix, restarted for each case.
+func Conformance(t *testing.T, serve func(w http.ResponseWriter, r *http.Request, err error), dir string) {
+	update := os.Getenv(UpdateEnv) == "1"
+	for _, c := range Cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			generator := ergo.DefaultIDGenerator
+			ergo.DefaultIDGenerator = &SequenceIDs{Prefix: "err-"}
+			defer func() { ergo.DefaultIDGenerator = generator }()
+
+			recorder := httptest.NewRecorder()
+			serve(recorder, httptest.NewRequest(http.MethodGet, "/", nil), c.Err)
+			actual := fmt.Sprintf("%d\n%s\n\n%s", recorder.Code, recorder.Header().Get("Content-Type"), recorder.Body.String())
+
+			path := filepath.Join(dir, c.Name+".golden")
+			if update {
+				if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			expected, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading golden file: %v (set %s=1 to create it)", err, UpdateEnv)
+			}
+			if actual != string(expected) {
+				t.Errorf("response does not match %s\nexpected:\n%s\nactual:\n%s", path, expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package ergotest
+
+import (
+	"testing"
+
+	"github.com/skullflow/ergo"
+)
+
+func TestConformance(t *testing.T) {
+	Conformance(t, ergo.ServeError, "testdata")
+}
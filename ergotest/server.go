@@ -0,0 +1,139 @@
+package ergotest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skullflow/ergo"
+)
+
+// HandlerFunc is an endpoint of a Server, whose error is served with ergo.ServeError
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Scenario defines a canned endpoint exercising the lifecycle of an error
+type Scenario struct {
+	Name    string
+	Path    string
+	Handler HandlerFunc
+}
+
+// Scenarios are the canned scenarios of the lifecycle of the errors
+var Scenarios = []Scenario{
+	{Name: "panic", Path: "/scenarios/panic", Handler: func(w http.ResponseWriter, r *http.Request) error {
+		panic("handler bug")
+	}},
+	{Name: "validation", Path: "/scenarios/validation", Handler: func(w http.ResponseWriter, r *http.Request) error {
+		return ergo.FieldRequired("email")
+	}},
+	{Name: "upstream_timeout", Path: "/scenarios/upstream_timeout", Handler: func(w http.ResponseWriter, r *http.Request) error {
+		return ergo.WithTimeout(r.Context(), time.Millisecond, "upstream.Call", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}},
+}
+
+// Server is an HTTP server serving the errors of its endpoints with ergo, capturing the
+// reported errors, the diagnostics and the stats for assertions. Panics of the endpoints
+// are recovered as internal errors.
+// While it runs, it replaces the Reporters and the OnDiagnostic of the package, so the
+// tests using it must not run in parallel.
+type Server struct {
+	*httptest.Server
+	Stats *ergo.Stats
+
+	mux         *http.ServeMux
+	mu          sync.Mutex
+	reports     []error
+	diagnostics []error
+}
+
+// NewServer starts a Server serving the canned Scenarios, whose endpoints are wrapped
+// in the middleware, in order. The server is closed and the package restored at the end of the test.
+func NewServer(t *testing.T, middleware ...func(http.Handler) http.Handler) *Server {
+	s := &Server{Stats: ergo.NewStats(time.Minute), mux: http.NewServeMux()}
+	var handler http.Handler = s.mux
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	s.Server = httptest.NewServer(handler)
+
+	reporters, onDiagnostic := ergo.Reporters, ergo.OnDiagnostic
+	ergo.Reporters = []ergo.Reporter{s}
+	ergo.OnDiagnostic = func(diagnostic error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.diagnostics = append(s.diagnostics, diagnostic)
+	}
+	t.Cleanup(func() {
+		s.Close()
+		ergo.Reporters, ergo.OnDiagnostic = reporters, onDiagnostic
+	})
+
+	for _, scenario := range Scenarios {
+		s.Handle(scenario.Path, scenario.Handler)
+	}
+	return s
+}
+
+// Handle registers the endpoint for the pattern of the path
+func (s *Server) Handle(pattern string, handler HandlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = &ergo.Error{Code: ergo.EINTERNAL, Op: "ergotest.Server", Err: fmt.Errorf("panic: %v", recovered)}
+			}
+			if err != nil {
+				s.Stats.Record(pattern, err)
+				ergo.ServeError(w, r, err)
+			}
+		}()
+		err = handler(w, r)
+	})
+}
+
+// Get requests the path and returns the status code and the error envelope of the response
+func (s *Server) Get(t *testing.T, path string) (int, ergo.JSONError) {
+	resp, err := s.Client().Get(s.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	var jsonError ergo.JSONError
+	if resp.StatusCode >= http.StatusBadRequest {
+		if err := json.Unmarshal(body, &jsonError); err != nil {
+			t.Fatalf("decoding the error envelope: %v", err)
+		}
+	}
+	return resp.StatusCode, jsonError
+}
+
+// Report captures the reported error, implementing ergo.Reporter
+func (s *Server) Report(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports = append(s.reports, err)
+}
+
+// Reports returns the errors reported by the package
+func (s *Server) Reports() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]error(nil), s.reports...)
+}
+
+// Diagnostics returns the diagnostics of the package
+func (s *Server) Diagnostics() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]error(nil), s.diagnostics...)
+}
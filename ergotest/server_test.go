@@ -0,0 +1,41 @@
+package ergotest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/skullflow/ergo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	server := NewServer(t)
+	server.Handle("/users", func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	// Test with the canned scenarios
+	status, jsonError := server.Get(t, "/scenarios/panic")
+	assert.Equal(t, 500, status)
+	assert.Equal(t, ergo.EINTERNAL, jsonError.Code)
+	assert.Equal(t, "An internal error has occurred.", jsonError.Message)
+
+	status, jsonError = server.Get(t, "/scenarios/validation")
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "email", jsonError.Details["field"])
+
+	status, jsonError = server.Get(t, "/scenarios/upstream_timeout")
+	assert.Equal(t, 504, status)
+	assert.Equal(t, ergo.ETIMEOUT, jsonError.Code)
+
+	// Test with a successful endpoint
+	status, _ = server.Get(t, "/users")
+	assert.Equal(t, 204, status)
+
+	reports := server.Reports()
+	assert.Len(t, reports, 3)
+	assert.Equal(t, "ergotest.Server: panic: handler bug", reports[0].Error())
+	assert.Equal(t, map[string]int{ergo.EINTERNAL: 1, ergo.EINVALID: 1, ergo.ETIMEOUT: 1}, server.Stats.Counts())
+	assert.Empty(t, server.Diagnostics())
+}
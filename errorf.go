@@ -0,0 +1,57 @@
+package ergo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errorf returns an error with the code whose message is formatted like fmt.Errorf.
+// The message is sent to the client: do not format internal details in it.
+// When the format wraps an error with %w, the error wraps the formatted one, so errors.Is
+// and errors.As match it, and the message is the text before the %w verb, without its
+// trailing separators, e.g. "Cannot invite the user" for "Cannot invite the user: %w",
+// or the message of the code if there is none, so that the text of the wrapped error,
+// e.g. the one of a driver, only reaches the logs.
+func Errorf(code string, format string, args ...interface{}) *Error {
+	formatted := fmt.Errorf(format, args...)
+	if errors.Unwrap(formatted) == nil {
+		return construct(&Error{Code: code, Message: formatted.Error()})
+	}
+	message := wrapPrefix(format, args)
+	if message == "" {
+		message = ErrorMessage(&Error{Code: code})
+	}
+	return construct(&Error{Code: code, Message: message, Err: formatted})
+}
+
+// wrapPrefix returns the text of the format before its %w verb, formatted with the
+// arguments of its verbs and without the trailing separators
+func wrapPrefix(format string, args []interface{}) string {
+	verbs := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			i++
+			continue
+		}
+		// Scan the flags, the width and the precision up to the verb
+		j := i + 1
+		for ; j < len(format) && strings.IndexByte("+-# 0123456789.*", format[j]) >= 0; j++ {
+			if format[j] == '*' {
+				verbs++
+			}
+		}
+		if j < len(format) && format[j] == 'w' {
+			if verbs > len(args) {
+				verbs = len(args)
+			}
+			return strings.TrimRight(fmt.Sprintf(format[:i], args[:verbs]...), " :;,-")
+		}
+		verbs++
+		i = j
+	}
+	return ""
+}
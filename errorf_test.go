@@ -0,0 +1,42 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorf(t *testing.T) {
+	// Test without wrapped error
+	err := Errorf(EINVALID, "Field %s is required.", "email")
+	assert.Equal(t, &Error{Code: EINVALID, Message: "Field email is required."}, err)
+
+	// Test with a wrapped error
+	inner := &Error{Code: ENOTFOUND, Op: "user.Get"}
+	err = Errorf(ECONFLICT, "Cannot invite the user: %w", inner)
+	assert.Equal(t, ECONFLICT, ErrorCode(err))
+	assert.Equal(t, "Cannot invite the user", ErrorMessage(err))
+	assert.Equal(t, "Cannot invite the user: user.Get: <not_found>", err.Err.Error())
+	assert.True(t, errors.Is(err, &Error{Code: ENOTFOUND}))
+	var target *Error
+	assert.True(t, errors.As(err.Err, &target))
+	assert.Equal(t, "user.Get", target.Op)
+
+	// Test that the text of a wrapped driver error does not reach the client
+	driverErr := errors.New(`pq: relation "users" does not exist`)
+	jsonError := FormatError(Errorf(EINVALID, "Cannot list the users: %w", driverErr))
+	assert.Equal(t, "Cannot list the users", jsonError.Message)
+	assert.NotContains(t, jsonError.Message, "pq:")
+	jsonError = FormatError(Errorf(EINTERNAL, "%w", driverErr))
+	assert.Equal(t, "An internal error has occurred.", jsonError.Message)
+
+	// Test with the arguments before the wrapped error
+	err = Errorf(ECONFLICT, "User %s (%d%%) is %*d: %w", "usr_7", 50, 3, 1, inner)
+	assert.Equal(t, "User usr_7 (50%) is   1", err.Message)
+	assert.Equal(t, &Error{Code: ECONFLICT, Message: "User usr_7 (50%) is   1", Err: err.Err}, err)
+
+	// Test with a wrapped error only, with the message of the code
+	err = Errorf(ECONFLICT, "%w", inner)
+	assert.Equal(t, &Error{Code: ECONFLICT, Message: "Conflict error.", Err: err.Err}, err)
+}
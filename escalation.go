@@ -0,0 +1,71 @@
+package ergo
+
+import (
+	"sync"
+	"time"
+)
+
+// EscalationRule sends the matching errors to its Reporters once Threshold of them
+// are handled within Window. A Threshold lower than 2 sends every matching error.
+type EscalationRule struct {
+	Match     Matcher
+	Threshold int
+	Window    time.Duration
+	Reporters []Reporter
+}
+
+// Escalation is a Reporter routing the handled errors to the reporters of the first
+// matching rule, e.g. metrics only for ECONFLICT but paging for EINTERNAL in billing.
+// Errors matching no rule are not reported.
+// It is safe for concurrent use.
+type Escalation struct {
+	Rules []EscalationRule
+
+	mu      sync.Mutex
+	windows map[int]*escalationWindow
+}
+
+type escalationWindow struct {
+	start time.Time
+	count int
+}
+
+// Report sends the error to the reporters of the first matching rule, when its threshold is reached
+func (e *Escalation) Report(err error) {
+	for i, rule := range e.Rules {
+		if !rule.Match(err) {
+			continue
+		}
+		if e.reached(i, rule) {
+			for _, reporter := range rule.Reporters {
+				reporter.Report(err)
+			}
+		}
+		return
+	}
+}
+
+// reached counts the error in the window of the rule and reports whether the threshold is reached
+func (e *Escalation) reached(index int, rule EscalationRule) bool {
+	if rule.Threshold < 2 {
+		return true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.windows == nil {
+		e.windows = make(map[int]*escalationWindow)
+	}
+	now := now()
+	window, ok := e.windows[index]
+	if !ok || (rule.Window > 0 && now.Sub(window.start) > rule.Window) {
+		window = &escalationWindow{start: now}
+		e.windows[index] = window
+	}
+	window.count++
+	if window.count < rule.Threshold {
+		return false
+	}
+	delete(e.windows, index)
+	return true
+}
@@ -0,0 +1,37 @@
+package ergo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscalation(t *testing.T) {
+	metrics, pager := &captureReporter{}, &captureReporter{}
+	escalation := &Escalation{
+		Rules: []EscalationRule{
+			{Match: MatchCode(ECONFLICT), Reporters: []Reporter{metrics}},
+			{Match: And(MatchCode(EINTERNAL), MatchOpPrefix("billing.")), Reporters: []Reporter{metrics, pager}},
+			{Match: MatchCode(EINTERNAL), Threshold: 2, Window: time.Minute, Reporters: []Reporter{pager}},
+		},
+	}
+
+	conflict := &Error{Code: ECONFLICT, Op: "billing.Charge"}
+	billing := &Error{Code: EINTERNAL, Op: "billing.Charge"}
+	internal := &Error{Code: EINTERNAL, Op: "user.Get"}
+	escalation.Report(conflict)
+	escalation.Report(billing)
+	escalation.Report(internal)
+	escalation.Report(&Error{Code: EINVALID})
+	assert.Equal(t, []error{conflict, billing}, metrics.reported())
+	assert.Equal(t, []error{billing}, pager.reported())
+
+	// Test that the threshold is reached
+	escalation.Report(internal)
+	assert.Equal(t, []error{billing, internal}, pager.reported())
+
+	// Test that the window is reset once the threshold is reached
+	escalation.Report(internal)
+	assert.Len(t, pager.reported(), 2)
+}
@@ -0,0 +1,80 @@
+package ergo
+
+// Conventional keys of the error details, sent to the client, and of the error fields:
+// the identifiers of the user, the request and the trace, and the locale, which are
+// internal and only reported.
+const (
+	FieldResourceType = "resource_type"
+	FieldResourceID   = "resource_id"
+	FieldUserID       = "user_id"
+	FieldRequestID    = "request_id"
+	FieldTraceID      = "trace_id"
+	FieldLocale       = "locale"
+)
+
+// SetResource sets the type and identifier of the resource the error refers to
+func (err *Error) SetResource(resourceType string, resourceID string) *Error {
+	return err.setDetail(FieldResourceType, resourceType).setDetail(FieldResourceID, resourceID)
+}
+
+// SetUserID sets the identifier of the user the error refers to, in the fields
+func (err *Error) SetUserID(userID string) *Error {
+	return err.setField(FieldUserID, userID)
+}
+
+// SetRequestID sets the identifier of the request that has generated the error, in the fields
+func (err *Error) SetRequestID(requestID string) *Error {
+	return err.setField(FieldRequestID, requestID)
+}
+
+// ErrorResourceType returns the type of the resource the error refers to, if available
+func ErrorResourceType(err error) string {
+	return detailString(err, FieldResourceType)
+}
+
+// ErrorResourceID returns the identifier of the resource the error refers to, if available
+func ErrorResourceID(err error) string {
+	return detailString(err, FieldResourceID)
+}
+
+// ErrorUserID returns the identifier of the user the error refers to, if available
+func ErrorUserID(err error) string {
+	return fieldString(err, FieldUserID)
+}
+
+// ErrorRequestID returns the identifier of the request that has generated the error, if available
+func ErrorRequestID(err error) string {
+	return fieldString(err, FieldRequestID)
+}
+
+// setDetail sets a key of the details, allocating them if needed
+func (err *Error) setDetail(key string, value interface{}) *Error {
+	err = err.own()
+	if err.Details == nil {
+		err.Details = make(map[string]interface{})
+	}
+	err.Details[key] = value
+	return err
+}
+
+// setField sets a key of the fields, allocating them if needed
+func (err *Error) setField(key string, value interface{}) *Error {
+	err = err.own()
+	if err.Fields == nil {
+		err.Fields = make(map[string]interface{})
+	}
+	err.Fields[key] = value
+	return err
+}
+
+// fieldString returns the string value of a key of the error fields
+func fieldString(err error, key string) string {
+	value, _ := ErrorFields(err)[key].(string)
+	return value
+}
+
+// detailString returns the string value of a key of the error details
+func detailString(err error, key string) string {
+	value, _ := ErrorDetails(err)[key].(string)
+	return value
+}
@@ -0,0 +1,29 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields(t *testing.T) {
+	error := (&Error{Code: ENOTFOUND}).SetResource("invoice", "inv_42").SetUserID("usr_7")
+	wrapped := (&Error{Op: "billing.Get", Err: error}).SetRequestID("req_1")
+
+	assert.Equal(t, "invoice", ErrorResourceType(wrapped))
+	assert.Equal(t, "inv_42", ErrorResourceID(wrapped))
+	assert.Equal(t, "usr_7", ErrorUserID(wrapped))
+	assert.Equal(t, "req_1", ErrorRequestID(wrapped))
+	assert.Equal(t, "inv_42", FormatError(wrapped).Details[FieldResourceID])
+
+	// Test with the internal identifiers, not sent to the client
+	assert.Equal(t, map[string]interface{}{FieldUserID: "usr_7", FieldRequestID: "req_1"}, ErrorFields(wrapped))
+	assert.NotContains(t, FormatError(wrapped).Details, FieldUserID)
+	assert.NotContains(t, FormatError(wrapped).Details, FieldRequestID)
+
+	// Test without fields
+	assert.Equal(t, "", ErrorRequestID(errors.New("some error")))
+	assert.Equal(t, "", ErrorUserID(&Error{Fields: map[string]interface{}{FieldUserID: 7}}))
+	assert.Equal(t, "", ErrorUserID(&Error{Details: map[string]interface{}{FieldUserID: "usr_7"}}))
+}
@@ -0,0 +1,33 @@
+package ergo
+
+import "context"
+
+// FlagEnabled evaluates a feature flag for the context, e.g. through the flag system
+// of the application, which can roll it out by percentage or tenant.
+// By default every flag is disabled.
+var FlagEnabled = func(ctx context.Context, flag string) bool {
+	return false
+}
+
+// FlaggedStatus defines a status code used only when Flag is enabled
+type FlaggedStatus struct {
+	Flag   string `json:"flag"`
+	Status int    `json:"status"`
+}
+
+// FlaggedStatusCodes maps the application error codes to their flagged status code
+var FlaggedStatusCodes = map[string]FlaggedStatus{}
+
+// ErrorStatusCodeContext works like ErrorStatusCode, using the flagged status code
+// of the error code when its flag is enabled for the context.
+func ErrorStatusCodeContext(ctx context.Context, err error) int {
+	return errorStatusCodeContext(contextPolicy(ctx), ctx, err)
+}
+
+// errorStatusCodeContext returns the status code of the error for the context under the policy
+func errorStatusCodeContext(p *Config, ctx context.Context, err error) int {
+	if flagged, ok := p.FlaggedStatusCodes[errorCode(p, err)]; ok && FlagEnabled(ctx, flagged.Flag) {
+		return flagged.Status
+	}
+	return errorStatusCode(p, err)
+}
@@ -0,0 +1,39 @@
+package ergo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flagKey struct{}
+
+func TestErrorStatusCodeContext(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	defer func() {
+		FlagEnabled = func(ctx context.Context, flag string) bool { return false }
+		FlaggedStatusCodes = map[string]FlaggedStatus{}
+	}()
+	FlagEnabled = func(ctx context.Context, flag string) bool {
+		return ctx.Value(flagKey{}) == flag
+	}
+	FlaggedStatusCodes[EINVALID] = FlaggedStatus{Flag: "unprocessable", Status: http.StatusUnprocessableEntity}
+	error := &Error{Code: EINVALID}
+
+	// Test with flag disabled
+	actual := ErrorStatusCodeContext(context.Background(), error)
+	assert.Equal(t, http.StatusBadRequest, actual)
+
+	// Test with flag enabled
+	ctx := context.WithValue(context.Background(), flagKey{}, "unprocessable")
+	actual = ErrorStatusCodeContext(ctx, error)
+	assert.Equal(t, http.StatusUnprocessableEntity, actual)
+
+	recorder := httptest.NewRecorder()
+	WriteErrorContext(ctx, recorder, error)
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.JSONEq(t, `{"code":"invalid","error_id":"err-1","code_id":2,"status_code":422,"message":"Bad request."}`, recorder.Body.String())
+}
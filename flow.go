@@ -0,0 +1,90 @@
+package ergo
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FlowEdge defines a transition of the handled errors from the operation that returned
+// them to the operation that wrapped them, with the number of errors that took it.
+type FlowEdge struct {
+	From  string
+	To    string
+	Count int
+}
+
+// Flow is a Reporter aggregating the operation chains of the handled errors, exported
+// as DOT or Mermaid graphs of where the errors flow through the codebase.
+// It is safe for concurrent use.
+type Flow struct {
+	mu    sync.Mutex
+	edges map[[2]string]int
+}
+
+// NewFlow returns an empty Flow
+func NewFlow() *Flow {
+	return &Flow{edges: map[[2]string]int{}}
+}
+
+// Report records the transitions of the operation chain of the error
+func (f *Flow) Report(err error) {
+	ops := errorOps(err)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(ops) - 1; i > 0; i-- {
+		f.edges[[2]string{ops[i], ops[i-1]}]++
+	}
+}
+
+// Edges returns the transitions, sorted by operations
+func (f *Flow) Edges() []FlowEdge {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	edges := make([]FlowEdge, 0, len(f.edges))
+	for edge, count := range f.edges {
+		edges = append(edges, FlowEdge{From: edge[0], To: edge[1], Count: count})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+// WriteDOT writes the flow as a Graphviz DOT digraph, labeling the edges with their count
+func (f *Flow) WriteDOT(w io.Writer) error {
+	var builder strings.Builder
+	builder.WriteString("digraph errors {\n")
+	for _, edge := range f.Edges() {
+		fmt.Fprintf(&builder, "\t%q -> %q [label=\"%d\"];\n", edge.From, edge.To, edge.Count)
+	}
+	builder.WriteString("}\n")
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// WriteMermaid writes the flow as a Mermaid flowchart, labeling the edges with their count
+func (f *Flow) WriteMermaid(w io.Writer) error {
+	ids := map[string]string{}
+	id := func(op string) string {
+		if _, ok := ids[op]; !ok {
+			ids[op] = fmt.Sprintf("op%d", len(ids))
+			return fmt.Sprintf("%s[\"%s\"]", ids[op], strings.ReplaceAll(op, `"`, "#quot;"))
+		}
+		return ids[op]
+	}
+
+	var builder strings.Builder
+	builder.WriteString("flowchart LR\n")
+	for _, edge := range f.Edges() {
+		from := id(edge.From)
+		fmt.Fprintf(&builder, "    %s -->|%d| %s\n", from, edge.Count, id(edge.To))
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
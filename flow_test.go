@@ -0,0 +1,41 @@
+package ergo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlow(t *testing.T) {
+	flow := NewFlow()
+	query := &Error{Op: "db.Query", Code: EINTERNAL}
+	flow.Report(&Error{Op: "http.CreateUser", Err: &Error{Op: "user.Create", Err: query}})
+	flow.Report(&Error{Op: "http.GetUser", Err: &Error{Op: "user.Get", Err: query}})
+	flow.Report(&Error{Op: "http.GetUser", Err: &Error{Op: "user.Get", Err: query}})
+	flow.Report(&Error{Op: "user.Get"})
+
+	assert.Equal(t, []FlowEdge{
+		{From: "db.Query", To: "user.Create", Count: 1},
+		{From: "db.Query", To: "user.Get", Count: 2},
+		{From: "user.Create", To: "http.CreateUser", Count: 1},
+		{From: "user.Get", To: "http.GetUser", Count: 2},
+	}, flow.Edges())
+
+	var dot bytes.Buffer
+	assert.NoError(t, flow.WriteDOT(&dot))
+	assert.Equal(t, "digraph errors {\n"+
+		"\t\"db.Query\" -> \"user.Create\" [label=\"1\"];\n"+
+		"\t\"db.Query\" -> \"user.Get\" [label=\"2\"];\n"+
+		"\t\"user.Create\" -> \"http.CreateUser\" [label=\"1\"];\n"+
+		"\t\"user.Get\" -> \"http.GetUser\" [label=\"2\"];\n"+
+		"}\n", dot.String())
+
+	var mermaid bytes.Buffer
+	assert.NoError(t, flow.WriteMermaid(&mermaid))
+	assert.Equal(t, "flowchart LR\n"+
+		"    op0[\"db.Query\"] -->|1| op1[\"user.Create\"]\n"+
+		"    op0 -->|2| op2[\"user.Get\"]\n"+
+		"    op1 -->|1| op3[\"http.CreateUser\"]\n"+
+		"    op2 -->|2| op4[\"http.GetUser\"]\n", mermaid.String())
+}
@@ -0,0 +1,35 @@
+package ergo
+
+import "net/http"
+
+// ForwardAuthChallenge is the WWW-Authenticate header sent with the 401 responses of
+// ServeForwardAuthError. The header is omitted when it is empty.
+var ForwardAuthChallenge = "Bearer"
+
+// ServeForwardAuthError works like ServeError for the auth server of a forward-auth
+// middleware, e.g. of Caddy or Traefik, whose response is returned to the client when
+// the request is denied. The reported error carries the forwarded request in its fields.
+func ServeForwardAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		ServeError(w, r, err)
+		return
+	}
+	if ErrorStatusCode(err) == http.StatusUnauthorized && ForwardAuthChallenge != "" {
+		w.Header().Set("WWW-Authenticate", ForwardAuthChallenge)
+	}
+
+	fields := map[string]interface{}{}
+	for field, header := range map[string]string{
+		"forwarded_method": "X-Forwarded-Method",
+		"forwarded_host":   "X-Forwarded-Host",
+		"forwarded_uri":    "X-Forwarded-Uri",
+	} {
+		if value := r.Header.Get(header); value != "" {
+			fields[field] = value
+		}
+	}
+	if len(fields) > 0 {
+		err = &Error{Err: err, Fields: fields}
+	}
+	ServeError(w, r, err)
+}
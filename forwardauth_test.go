@@ -0,0 +1,32 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeForwardAuthError(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	capture := &captureReporter{}
+	defer func() { Reporters = nil }()
+	Reporters = []Reporter{capture}
+
+	// Test with an unauthenticated request
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/auth", nil)
+	r.Header.Set("X-Forwarded-Method", "DELETE")
+	r.Header.Set("X-Forwarded-Uri", "/users/42")
+	ServeForwardAuthError(w, r, &Error{Code: EUNAUTHORIZED})
+	assert.Equal(t, 401, w.Code)
+	assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+	assert.JSONEq(t, `{"code":"unauthorized","error_id":"err-1","code_id":5,"status_code":401,"message":"Unauthorized."}`, w.Body.String())
+	assert.Equal(t, map[string]interface{}{"forwarded_method": "DELETE", "forwarded_uri": "/users/42"}, ErrorFields(capture.reported()[0]))
+
+	// Test with a forbidden request
+	w = httptest.NewRecorder()
+	ServeForwardAuthError(w, httptest.NewRequest("GET", "/auth", nil), &Error{Code: EFORBIDDEN})
+	assert.Equal(t, 403, w.Code)
+	assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+}
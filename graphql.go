@@ -0,0 +1,50 @@
+package ergo
+
+import "context"
+
+// GraphQLMask matches the errors whose message and details are masked in GraphQL responses
+var GraphQLMask Matcher = Is5xx
+
+// GraphQLError is an entry of the errors of a GraphQL response. It has the shape of the
+// gqlerror.Error of gqlgen, so that an error presenter can convert it field by field.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// FormatGraphQLError returns the GraphQL error of the resolver failing at path, formatted with
+// FormatErrorContext. EINVALID errors on a field extend the path with the field name,
+// and the errors matching GraphQLMask are masked. The extensions carry the error_id, also
+// of the masked errors, for the users to quote to support: Identify the error before
+// reporting and formatting it so that the logs record the same error_id.
+func FormatGraphQLError(ctx context.Context, err error, path []interface{}) GraphQLError {
+	err = Identify(err)
+	jsonError := FormatErrorContext(ctx, err)
+	if GraphQLMask != nil && GraphQLMask(err) {
+		return GraphQLError{
+			Message: ErrorMessage(&Error{Code: EINTERNAL}),
+			Path:    path,
+			Extensions: map[string]interface{}{
+				"code":     EINTERNAL,
+				"error_id": jsonError.ErrorID,
+			},
+		}
+	}
+
+	if field, ok := jsonError.Details["field"].(string); ok && jsonError.Code == EINVALID {
+		path = append(append([]interface{}{}, path...), field)
+	}
+	extensions := map[string]interface{}{
+		"code":     jsonError.Code,
+		"error_id": jsonError.ErrorID,
+	}
+	if len(jsonError.Details) > 0 {
+		extensions["details"] = jsonError.Details
+	}
+	return GraphQLError{
+		Message:    jsonError.Message,
+		Path:       path,
+		Extensions: extensions,
+	}
+}
@@ -0,0 +1,43 @@
+package ergo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatGraphQLError(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	path := []interface{}{"createUser", 0}
+
+	// Test with a field error
+	err := FormatGraphQLError(context.Background(), FieldRequired("email"), path)
+	assert.Equal(t, "email is required.", err.Message)
+	assert.Equal(t, []interface{}{"createUser", 0, "email"}, err.Path)
+	assert.Equal(t, EINVALID, err.Extensions["code"])
+	assert.Equal(t, "err-1", err.Extensions["error_id"])
+	assert.Equal(t, []interface{}{"createUser", 0}, path)
+
+	// Test with a masked error
+	err = FormatGraphQLError(context.Background(), &Error{Code: EINTERNAL, Message: "Database is down.", Details: map[string]interface{}{"host": "db"}}, path)
+	assert.Equal(t, GraphQLError{
+		Message:    "An internal error has occurred.",
+		Path:       path,
+		Extensions: map[string]interface{}{"code": EINTERNAL, "error_id": "err-1"},
+	}, err)
+
+	// Test without masking
+	defer func() { GraphQLMask = Is5xx }()
+	GraphQLMask = nil
+	err = FormatGraphQLError(context.Background(), errors.New("some error"), nil)
+	assert.Equal(t, "An internal error has occurred.", err.Message)
+	assert.Nil(t, err.Path)
+
+	// Test with an identified error
+	identified := Identify(&Error{Code: EUNAVAILABLE, ErrorID: "upstream-1"})
+	GraphQLMask = Is5xx
+	err = FormatGraphQLError(context.Background(), identified, nil)
+	assert.Equal(t, "upstream-1", err.Extensions["error_id"])
+}
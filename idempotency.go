@@ -0,0 +1,33 @@
+package ergo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// IdempotencyKeyHeader is the request header carrying the idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyMismatch returns an EIDEMPOTENCY error for an idempotency key replayed with
+// a request different from the original one, carrying the key and the fingerprint of the original request.
+func IdempotencyMismatch(key string, originalFingerprint string) *Error {
+	return construct(&Error{
+		Code: EIDEMPOTENCY,
+		Details: map[string]interface{}{
+			"idempotency_key":      key,
+			"original_fingerprint": originalFingerprint,
+		},
+	})
+}
+
+// RequestFingerprint returns the fingerprint identifying the payload of an idempotent request,
+// to be stored with its idempotency key and compared when the key is replayed.
+func RequestFingerprint(method string, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,21 @@
+package ergo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyMismatch(t *testing.T) {
+	original := RequestFingerprint("POST", "/payments", []byte(`{"amount":100}`))
+	assert.Len(t, original, 64)
+	assert.Equal(t, original, RequestFingerprint("POST", "/payments", []byte(`{"amount":100}`)))
+	assert.NotEqual(t, original, RequestFingerprint("POST", "/payments", []byte(`{"amount":200}`)))
+
+	err := IdempotencyMismatch("key-1", original)
+	assert.Equal(t, EIDEMPOTENCY, ErrorCode(err))
+	assert.Equal(t, 422, ErrorStatusCode(err))
+	assert.Equal(t, "Idempotency key already used.", ErrorMessage(err))
+	assert.Equal(t, map[string]interface{}{"idempotency_key": "key-1", "original_fingerprint": original}, ErrorDetails(err))
+	assert.Equal(t, 13, FormatError(err).CodeID)
+}
@@ -0,0 +1,125 @@
+package ergo
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The formats of the error_id, to assign to DefaultIDGenerator so that the error_id sent to
+// the clients and recorded by the reporters matches the keys of the log and trace systems
+var (
+	// UUIDv7 generates time-ordered UUIDs, version 7 (RFC 9562)
+	UUIDv7 IDGenerator = uuidV7IDs{}
+	// ULID generates time-ordered ULIDs, Crockford base32 encoded
+	ULID IDGenerator = ulidIDs{}
+	// KSUID generates time-ordered KSUIDs, base62 encoded
+	KSUID IDGenerator = ksuidIDs{}
+)
+
+// IDGeneratorFunc is an IDGenerator calling the function, to plug a custom format
+type IDGeneratorFunc func() string
+
+// NewID returns the identifier returned by the function
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// uuidV7IDs generates UUIDs v7: 48 bits of milliseconds followed by 74 random bits
+type uuidV7IDs struct{}
+
+func (uuidV7IDs) NewID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id[6:])
+	putMillis(id, now())
+	id[6] = id[6]&0x0f | 0x70
+	id[8] = id[8]&0x3f | 0x80
+	encoded := hex.EncodeToString(id)
+	return encoded[:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:]
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidIDs generates ULIDs: 48 bits of milliseconds followed by 80 random bits
+type ulidIDs struct{}
+
+func (ulidIDs) NewID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id[6:])
+	putMillis(id, now())
+	n := new(big.Int).SetBytes(id)
+	encoded := make([]byte, 26)
+	mask := big.NewInt(31)
+	for i := len(encoded) - 1; i >= 0; i-- {
+		encoded[i] = crockford[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 5)
+	}
+	return string(encoded)
+}
+
+const (
+	base62     = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidEpoch = 1400000000
+)
+
+// ksuidIDs generates KSUIDs: 32 bits of seconds since the KSUID epoch followed by 128 random bits
+type ksuidIDs struct{}
+
+func (ksuidIDs) NewID() string {
+	id := make([]byte, 20)
+	binary.BigEndian.PutUint32(id, uint32(now().Unix()-ksuidEpoch))
+	_, _ = rand.Read(id[4:])
+	n := new(big.Int).SetBytes(id)
+	encoded := make([]byte, 27)
+	radix, digit := big.NewInt(62), new(big.Int)
+	for i := len(encoded) - 1; i >= 0; i-- {
+		n.DivMod(n, radix, digit)
+		encoded[i] = base62[digit.Int64()]
+	}
+	return string(encoded)
+}
+
+// putMillis writes the unix milliseconds of t in the first 48 bits of id
+func putMillis(id []byte, t time.Time) {
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	for i := 5; i >= 0; i-- {
+		id[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// SnowflakeEpoch is the epoch of the Snowflake identifiers, the one of Twitter
+var SnowflakeEpoch = time.Unix(0, 1288834974657*int64(time.Millisecond))
+
+// Snowflake generates Snowflake identifiers: 41 bits of milliseconds since the SnowflakeEpoch,
+// 10 bits of node and 12 bits of sequence, formatted in decimal. It is safe for concurrent use.
+type Snowflake struct {
+	node int64
+
+	mu       sync.Mutex
+	last     int64
+	sequence int64
+}
+
+// NewSnowflake returns a Snowflake generator for the node, between 0 and 1023
+func NewSnowflake(node int64) *Snowflake {
+	return &Snowflake{node: node & 0x3ff}
+}
+
+// NewID returns the next identifier of the node. When the 4096 identifiers of
+// a millisecond are exhausted, it borrows from the next millisecond.
+func (s *Snowflake) NewID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ms := int64(now().Sub(SnowflakeEpoch) / time.Millisecond)
+	if ms > s.last {
+		s.last, s.sequence = ms, 0
+	} else if s.sequence++; s.sequence > 0xfff {
+		s.last, s.sequence = s.last+1, 0
+	}
+	return strconv.FormatInt(s.last<<22|s.node<<12|s.sequence, 10)
+}
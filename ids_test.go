@@ -0,0 +1,51 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDFormats(t *testing.T) {
+	defer func() {
+		DefaultClock = systemClock{}
+	}()
+	DefaultClock = fixedClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	// Test with UUIDv7, whose timestamp is 0x018df9e2b200
+	id := UUIDv7.NewID()
+	assert.Regexp(t, regexp.MustCompile(`^018df9e2-b200-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), id)
+	assert.NotEqual(t, id, UUIDv7.NewID())
+
+	// Test with ULID
+	id = ULID.NewID()
+	assert.Regexp(t, regexp.MustCompile(`^01HQWY5CG0[0-9A-HJKMNP-TV-Z]{16}$`), id)
+
+	// Test with KSUID
+	id = KSUID.NewID()
+	assert.Len(t, id, 27)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9A-Za-z]+$`), id)
+
+	// Test with Snowflake, the sequence increments within the millisecond
+	snowflake := NewSnowflake(1)
+	assert.Equal(t, "1763534649553850368", snowflake.NewID())
+	assert.Equal(t, "1763534649553850369", snowflake.NewID())
+
+	// Test with the error_id of the envelope
+	defer func() {
+		DefaultIDGenerator = randomIDs{}
+	}()
+	DefaultIDGenerator = ULID
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, &Error{Code: ENOTFOUND})
+	assert.Regexp(t, regexp.MustCompile(`"error_id":"01HQWY5CG0[0-9A-HJKMNP-TV-Z]{16}"`), recorder.Body.String())
+
+	// Test with a custom generator
+	DefaultIDGenerator = IDGeneratorFunc(func() string { return "custom" })
+	assert.Equal(t, "custom", NewEvent(&Error{Code: EINVALID}).ID)
+	_, jsonError := HandleError(&Error{Code: EINVALID})
+	assert.Equal(t, "custom", jsonError.ErrorID)
+}
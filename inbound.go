@@ -0,0 +1,53 @@
+package ergo
+
+import (
+	"fmt"
+	"time"
+)
+
+// Reasons of the verification failures of inbound webhooks
+const (
+	ReasonInvalidSignature = "invalid_signature"
+	ReasonStaleTimestamp   = "stale_timestamp"
+	ReasonReplayed         = "replayed"
+)
+
+// InvalidSignature returns an EUNAUTHORIZED error for an inbound webhook whose signature,
+// carried in the header, is missing or does not match the payload.
+func InvalidSignature(header string) *Error {
+	return webhookError(ReasonInvalidSignature, fmt.Sprintf("Signature in %s is invalid.", header), map[string]interface{}{
+		"header": header,
+	})
+}
+
+// StaleTimestamp returns an EUNAUTHORIZED error for an inbound webhook signed at timestamp,
+// outside the tolerance of the receiver.
+func StaleTimestamp(timestamp time.Time, tolerance time.Duration) *Error {
+	return webhookError(ReasonStaleTimestamp, "Signature timestamp is outside the tolerance.", map[string]interface{}{
+		"timestamp":         timestamp.Unix(),
+		"tolerance_seconds": int64(tolerance / time.Second),
+	})
+}
+
+// ReplayedDelivery returns an EUNAUTHORIZED error for an inbound webhook whose delivery
+// has already been received.
+func ReplayedDelivery(deliveryID string) *Error {
+	return webhookError(ReasonReplayed, "Delivery has already been received.", map[string]interface{}{
+		"delivery_id": deliveryID,
+	})
+}
+
+// webhookError returns an EUNAUTHORIZED error whose details carry the reason of the failure
+func webhookError(reason string, message string, params map[string]interface{}) *Error {
+	details := map[string]interface{}{
+		"reason": reason,
+	}
+	for key, value := range params {
+		details[key] = value
+	}
+	return construct(&Error{
+		Code:    EUNAUTHORIZED,
+		Message: message,
+		Details: details,
+	})
+}
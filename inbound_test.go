@@ -0,0 +1,22 @@
+package ergo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboundWebhookErrors(t *testing.T) {
+	err := InvalidSignature(SignatureHeader)
+	assert.Equal(t, EUNAUTHORIZED, ErrorCode(err))
+	assert.Equal(t, "Signature in Ergo-Signature is invalid.", ErrorMessage(err))
+	assert.Equal(t, map[string]interface{}{"reason": ReasonInvalidSignature, "header": SignatureHeader}, ErrorDetails(err))
+
+	err = StaleTimestamp(time.Unix(1700000000, 0), 5*time.Minute)
+	assert.Equal(t, map[string]interface{}{"reason": ReasonStaleTimestamp, "timestamp": int64(1700000000), "tolerance_seconds": int64(300)}, ErrorDetails(err))
+
+	err = ReplayedDelivery("evt_1")
+	assert.Equal(t, 401, ErrorStatusCode(err))
+	assert.Equal(t, map[string]interface{}{"reason": ReasonReplayed, "delivery_id": "evt_1"}, ErrorDetails(err))
+}
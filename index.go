@@ -14,6 +14,20 @@ const (
 	ENOTFOUND     = "not_found"    // Entity does not exist
 	EUNAUTHORIZED = "unauthorized" // User unauthorized
 	EFORBIDDEN    = "forbidden"    // User cannot access the resources
+
+	EPRECONDITION         = "precondition_failed"   // Precondition on the resource state failed
+	EPRECONDITIONREQUIRED = "precondition_required" // Request must be conditional
+	ERANGE                = "range_not_satisfiable" // Requested range is outside the resource
+	EPAYLOADTOOLARGE      = "payload_too_large"     // Request body exceeds the limit
+	EUNSUPPORTEDMEDIA     = "unsupported_media"     // Request body format is not supported
+	ETOOMANYREQUESTS      = "too_many_requests"     // Rate limit exceeded
+	EIDEMPOTENCY          = "idempotency_mismatch"  // Idempotency key reused with a different request
+	EGONE                 = "gone"                  // Entity has been deleted
+	EUNAVAILABLE          = "unavailable"           // Service is temporarily unavailable
+	EPAYMENTREQUIRED      = "payment_required"      // Plan of the account does not allow the action
+	ETIMEOUT              = "timeout"               // Operation did not complete in time
+	EMETHODNOTALLOWED     = "method_not_allowed"    // Method is not supported by the resource
+	EPOLICYVIOLATION      = "policy_violation"      // Content violates the policies of the service
 )
 
 // Error defines a standard application error
@@ -21,18 +35,43 @@ const (
 // Message is a Human-readable message
 // Op is the logical operation that has generated the error
 // Err is the error generated
+// Details are machine-readable data sent to the client
+// Cause is the root cause classification, for postmortem analytics
+// Hops are the services the error has been propagated from
+// Tags label the error for the rules of the package, they are not sent to the client
+// Fields are structured data for logs and dashboards, they are not sent to the client
+// Stack is the call stack where the error has been created, if captured
+// ErrorID identifies the occurrence of the error, sent to the client to quote to support
 type Error struct {
 	Code    string
 	Message string
 	Op      string
 	Err     error
+	Details map[string]interface{}
+	Cause   string
+	Hops    []Hop
+	Tags    []string
+	Fields  map[string]interface{}
+	Stack   []Frame
+	ErrorID string
+
+	// shared marks the sentinels, which the mutators copy instead of modifying
+	shared bool
 }
 
 // JSON Error defines the error to send to client
 type JSONError struct {
-	Code       string `json:"code"`
-	StatusCode int    `json:"status_code"`
-	Message    string `json:"message"`
+	Code       string                 `json:"code"`
+	ErrorID    string                 `json:"error_id,omitempty"`
+	CodeID     int                    `json:"code_id,omitempty"`
+	StatusCode int                    `json:"status_code"`
+	Message    string                 `json:"message"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Internal   string                 `json:"internal,omitempty"`
+	Origin     *Origin                `json:"origin,omitempty"`
+	Hops       []Hop                  `json:"hops,omitempty"`
+	RootCause  string                 `json:"root_cause,omitempty"`
+	Support    *Support               `json:"support,omitempty"`
 }
 
 // Error returns the string representation of the error message.
@@ -57,83 +96,190 @@ func (err *Error) Error() string {
 	return buffer.String()
 }
 
+// Unwrap returns the wrapped error, for errors.Is and errors.As
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Is reports whether the target is an application error with the same code, regardless
+// of its message and operation, e.g. errors.Is(err, &Error{Code: ENOTFOUND}) or
+// errors.Is(err, Sentinel(ENOTFOUND)). A target without code matches nothing.
+func (err *Error) Is(target error) bool {
+	t, isCustomError := target.(*Error)
+	return isCustomError && t.Code != "" && err.Code == t.Code
+}
+
+// wrapper is implemented by the errors wrapping another one, e.g. with fmt.Errorf("%w")
+type wrapper interface {
+	Unwrap() error
+}
+
 // ErrorCode returns the code of the root error, if available.
+// When several errors of the stack have a code, Inheritance decides which one is returned.
+// Joined errors return the code of their most severe member, and the errors wrapped
+// with fmt.Errorf("%w") the code of the error they wrap.
 // Otherwise returns EINTERNAL.
 func ErrorCode(err error) string {
+	return errorCode(policy(), err)
+}
+
+// errorCode returns the code of the error under the policy
+func errorCode(p *Config, err error) string {
 	if err == nil {
 		return ""
-	} else if e, isCustomError := err.(*Error); isCustomError && e.Code != "" {
+	} else if joined, ok := err.(multiError); ok {
+		return errorCode(p, mostSevere(p, joined.Unwrap()))
+	} else if e, isCustomError := err.(*Error); isCustomError && e.Code != "" && !innerClassified(e) {
 		return e.Code
 	} else if isCustomError && e.Err != nil {
-		return ErrorCode(e.Err)
+		return errorCode(p, e.Err)
+	} else if wrapped, ok := err.(wrapper); ok && !isCustomError {
+		return errorCode(p, wrapped.Unwrap())
 	}
 	return EINTERNAL
 }
 
 // ErrorMessage returns the human-readable message of the error, if available.
+// Joined errors return the message of their most severe member, and the errors wrapped
+// with fmt.Errorf("%w") the message of the error they wrap.
 // Otherwise returns a generic error message.
 func ErrorMessage(err error) string {
+	return errorMessage(policy(), err)
+}
+
+// errorMessage returns the message of the error under the policy
+func errorMessage(p *Config, err error) string {
 	if err == nil {
 		return ""
+	} else if joined, ok := err.(multiError); ok {
+		return errorMessage(p, mostSevere(p, joined.Unwrap()))
 	} else if e, isCustomError := err.(*Error); isCustomError && e.Message != "" {
 		return e.Message
 	} else if isCustomError && e.Err != nil {
-		return ErrorMessage(e.Err)
-	} else if isCustomError && e.Code != "" {
-		// If the message is not present, try to infer it from the Code
-		switch e.Code {
-		case ECONFLICT:
-			return "Conflict error."
-		case EINTERNAL:
-			return "An internal error has occurred."
-		case EINVALID:
-			return "Bad request."
-		case ENOTFOUND:
-			return "Resource not found."
-		case EUNAUTHORIZED:
-			return "Unauthorized."
-		case EFORBIDDEN:
-			return "Forbidden."
-		}
+		return errorMessage(p, e.Err)
+	} else if isCustomError && p.Codes[e.Code].Message != "" {
+		// If the message is not present, infer it from the Code
+		return p.Codes[e.Code].Message
+	} else if wrapped, ok := err.(wrapper); ok && !isCustomError {
+		return errorMessage(p, wrapped.Unwrap())
 	}
 	return "An internal error has occurred."
 }
 
 // ErrorStatusCode returns the status code of the http request.
+// Joined errors return the status code of their most severe member, and the errors wrapped
+// with fmt.Errorf("%w") the status code of the error they wrap.
 // Otherwise returns a 500 (internal server error)
 func ErrorStatusCode(err error) int {
-	if e, isCustomError := err.(*Error); isCustomError && e.Code != "" {
-		switch e.Code {
-		case ECONFLICT:
-			return http.StatusConflict
-		case EINTERNAL:
-			return http.StatusInternalServerError
-		case EINVALID:
-			return http.StatusBadRequest
-		case ENOTFOUND:
-			return http.StatusNotFound
-		case EUNAUTHORIZED:
-			return http.StatusUnauthorized
-		case EFORBIDDEN:
-			return http.StatusForbidden
+	return errorStatusCode(policy(), err)
+}
+
+// errorStatusCode returns the status code of the error under the policy
+func errorStatusCode(p *Config, err error) int {
+	if joined, ok := err.(multiError); ok {
+		return errorStatusCode(p, mostSevere(p, joined.Unwrap()))
+	} else if e, isCustomError := err.(*Error); isCustomError && e.Code != "" && !innerClassified(e) {
+		if status := p.Codes[e.Code].Status; status != 0 {
+			return status
 		}
 	} else if isCustomError && e.Err != nil {
-		return ErrorStatusCode(e.Err)
+		return errorStatusCode(p, e.Err)
+	} else if wrapped, ok := err.(wrapper); ok && !isCustomError {
+		return errorStatusCode(p, wrapped.Unwrap())
 	}
 	// Fallback
 	return http.StatusInternalServerError
 }
 
+// ErrorDetails returns the details of the error stack, if available.
+// Details of outer errors override the ones of the errors they wrap.
+func ErrorDetails(err error) map[string]interface{} {
+	e, isCustomError := err.(*Error)
+	if !isCustomError {
+		return nil
+	}
+	return mergeMaps(ErrorDetails(e.Err), e.Details)
+}
+
+// ErrorFields returns the fields of the error stack, if available.
+// Fields of outer errors override the ones of the errors they wrap.
+func ErrorFields(err error) map[string]interface{} {
+	e, isCustomError := err.(*Error)
+	if !isCustomError {
+		return nil
+	}
+	return mergeMaps(ErrorFields(e.Err), e.Fields)
+}
+
+// ErrorID returns the identifier of the occurrence of the error, the outermost one
+// of the stack, if available. The errors wrapped with fmt.Errorf("%w") are traversed.
+func ErrorID(err error) string {
+	if e, isCustomError := err.(*Error); isCustomError && e.ErrorID != "" {
+		return e.ErrorID
+	} else if isCustomError && e.Err != nil {
+		return ErrorID(e.Err)
+	} else if wrapped, ok := err.(wrapper); ok && !isCustomError {
+		return ErrorID(wrapped.Unwrap())
+	}
+	return ""
+}
+
+// Identify returns the error carrying an error_id: the error itself if its stack has one,
+// otherwise the error wrapped with an identifier generated by the DefaultIDGenerator.
+// The handlers of the package identify the errors before formatting and reporting them,
+// so that the envelope and the reports share the error_id; identify the errors formatted
+// and logged by other means with it.
+func Identify(err error) error {
+	if err == nil || ErrorID(err) != "" {
+		return err
+	}
+	return &Error{Err: err, ErrorID: DefaultIDGenerator.NewID()}
+}
+
+// mergeMaps copies the values of outer into inner, allocating it if needed
+func mergeMaps(inner map[string]interface{}, outer map[string]interface{}) map[string]interface{} {
+	if len(outer) == 0 {
+		return inner
+	}
+	if inner == nil {
+		inner = make(map[string]interface{}, len(outer))
+	}
+	for key, value := range outer {
+		inner[key] = value
+	}
+	return inner
+}
+
 // Format error will return a Json to be sent to the client describing the error
 func FormatError(err error) JSONError {
-	return JSONError{
-		Code:       ErrorCode(err),
-		StatusCode: ErrorStatusCode(err),
-		Message:    ErrorMessage(err),
+	p := policy()
+	return styleError(p, formatError(p, err))
+}
+
+// formatError formats the error under the policy, without applying the MessageStyle
+func formatError(p *Config, err error) JSONError {
+	details := ErrorDetails(err)
+	if ExpandJoined {
+		details = expandJoined(err, details)
 	}
+	code, status := errorCode(p, err), errorStatusCode(p, err)
+	return checkFormattedCode(p, JSONError{
+		Code:       code,
+		ErrorID:    ErrorID(err),
+		CodeID:     p.Codes[code].ID,
+		StatusCode: status,
+		Message:    errorMessage(p, err),
+		Details:    details,
+		Origin:     serviceOrigin(),
+		Hops:       ErrorHops(err),
+		Support:    errorSupport(err, status, DefaultSupport),
+	})
 }
 
-// HandleError will return a Json representation of the error and log the error
+// HandleError will return a Json representation of the error and report the error
 func HandleError(err error) (int, JSONError) {
-	return ErrorStatusCode(err), FormatError(err)
+	err = Identify(err)
+	report(err)
+	p := policy()
+	return errorStatusCode(p, err), styleError(p, formatError(p, err))
 }
@@ -0,0 +1,34 @@
+package ergo
+
+// Policies of inheritance of the code when wrapping a classified error
+const (
+	InheritOutermost = "outermost" // The code of the outermost error wins
+	InheritInnermost = "innermost" // The first classification, the innermost code, wins
+)
+
+// Inheritance is the policy deciding which code, and so status code,
+// is reported when an error with a code wraps another error with a code.
+var Inheritance = InheritOutermost
+
+// innerClassified reports whether the code of the wrapped error wins over the one of e
+func innerClassified(e *Error) bool {
+	return Inheritance == InheritInnermost && e.Err != nil && hasCode(e.Err)
+}
+
+// hasCode reports whether an error of the stack has a code
+func hasCode(err error) bool {
+	if joined, ok := err.(multiError); ok {
+		for _, member := range joined.Unwrap() {
+			if hasCode(member) {
+				return true
+			}
+		}
+	} else if e, isCustomError := err.(*Error); isCustomError && e.Code != "" {
+		return true
+	} else if isCustomError && e.Err != nil {
+		return hasCode(e.Err)
+	} else if wrapped, ok := err.(wrapper); ok && !isCustomError {
+		return hasCode(wrapped.Unwrap())
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package ergo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInheritance(t *testing.T) {
+	error := &Error{
+		Code: EINTERNAL,
+		Op:   "order.Create",
+		Err: &Error{
+			Op:  "stock.Reserve",
+			Err: &Error{Code: ECONFLICT},
+		},
+	}
+
+	// Test with the outermost code winning
+	assert.Equal(t, EINTERNAL, ErrorCode(error))
+	assert.Equal(t, http.StatusInternalServerError, ErrorStatusCode(error))
+
+	// Test with the innermost code winning
+	defer func() { Inheritance = InheritOutermost }()
+	Inheritance = InheritInnermost
+	assert.Equal(t, ECONFLICT, ErrorCode(error))
+	assert.Equal(t, http.StatusConflict, ErrorStatusCode(error))
+
+	// Test without inner code
+	error = &Error{Code: ENOTFOUND, Err: errors.New("no rows")}
+	assert.Equal(t, ENOTFOUND, ErrorCode(error))
+	assert.Equal(t, http.StatusNotFound, ErrorStatusCode(error))
+}
@@ -0,0 +1,53 @@
+// Package recorder records the error responses written by ergo, for the adapters
+// converting them into the responses of the serverless and proxy platforms.
+package recorder
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Response records the response written to it as an http.ResponseWriter
+type Response struct {
+	Status int
+	Body   []byte
+
+	header http.Header
+}
+
+// New returns an empty response, with the 200 status of the responses written without status
+func New() *Response {
+	return &Response{Status: http.StatusOK, header: http.Header{}}
+}
+
+func (r *Response) Header() http.Header {
+	return r.header
+}
+
+func (r *Response) Write(body []byte) (int, error) {
+	r.Body = append(r.Body, body...)
+	return len(body), nil
+}
+
+func (r *Response) WriteHeader(status int) {
+	r.Status = status
+}
+
+// Headers returns the single-value headers of the response, the values of each header
+// written several times, e.g. Vary and Link, joined with commas as allowed by RFC 9110
+func (r *Response) Headers() map[string]string {
+	headers := make(map[string]string, len(r.header))
+	for key, values := range r.header {
+		headers[key] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
+// MultiValueHeaders returns a copy of the headers of the response, with all their values
+func (r *Response) MultiValueHeaders() map[string][]string {
+	headers := make(map[string][]string, len(r.header))
+	for key, values := range r.header {
+		headers[key] = append([]string(nil), values...)
+	}
+	return headers
+}
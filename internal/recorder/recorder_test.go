@@ -0,0 +1,27 @@
+package recorder
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponse(t *testing.T) {
+	// Test without status
+	response := New()
+	_, _ = response.Write([]byte("{}"))
+	assert.Equal(t, http.StatusOK, response.Status)
+
+	response = New()
+	response.Header().Set("Content-Type", "application/json")
+	response.Header().Add("Vary", "Accept")
+	response.Header().Add("Vary", "Accept-Encoding")
+	response.WriteHeader(http.StatusNotFound)
+	_, _ = response.Write([]byte(`{"code":`))
+	_, _ = response.Write([]byte(`"not_found"}`))
+	assert.Equal(t, http.StatusNotFound, response.Status)
+	assert.Equal(t, `{"code":"not_found"}`, string(response.Body))
+	assert.Equal(t, map[string]string{"Content-Type": "application/json", "Vary": "Accept, Accept-Encoding"}, response.Headers())
+	assert.Equal(t, map[string][]string{"Content-Type": {"application/json"}, "Vary": {"Accept", "Accept-Encoding"}}, response.MultiValueHeaders())
+}
@@ -0,0 +1,54 @@
+package ergo
+
+// ExpandJoined makes FormatError list the members of errors created with
+// errors.Join under the "errors" key of the details.
+var ExpandJoined = false
+
+// multiError is implemented by the errors created with errors.Join
+type multiError interface {
+	Unwrap() []error
+}
+
+// mostSevere returns the member with the highest status code, the first one on ties
+func mostSevere(p *Config, errs []error) error {
+	var severe error
+	severeStatus := 0
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if status := errorStatusCode(p, err); status > severeStatus {
+			severe, severeStatus = err, status
+		}
+	}
+	return severe
+}
+
+// joinedErrors returns the members of the first joined error of the stack, if any
+func joinedErrors(err error) []error {
+	if joined, ok := err.(multiError); ok {
+		return joined.Unwrap()
+	} else if e, isCustomError := err.(*Error); isCustomError && e.Err != nil {
+		return joinedErrors(e.Err)
+	}
+	return nil
+}
+
+// expandJoined returns the details with the members of the joined error of the stack
+func expandJoined(err error, details map[string]interface{}) map[string]interface{} {
+	members := joinedErrors(err)
+	if len(members) == 0 {
+		return details
+	}
+	jsonErrors := make([]JSONError, 0, len(members))
+	for _, member := range members {
+		if member != nil {
+			jsonErrors = append(jsonErrors, FormatError(member))
+		}
+	}
+	if details == nil {
+		details = make(map[string]interface{}, 1)
+	}
+	details["errors"] = jsonErrors
+	return details
+}
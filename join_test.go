@@ -0,0 +1,69 @@
+package ergo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// joinError mimics the errors created with errors.Join, which requires Go 1.20
+type joinError []error
+
+func (errs joinError) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (errs joinError) Unwrap() []error {
+	return errs
+}
+
+func TestJoinedErrors(t *testing.T) {
+	error := joinError{
+		&Error{Code: EINVALID, Message: "email is invalid"},
+		&Error{Code: EINTERNAL, Message: "mailer is down"},
+		&Error{Code: ENOTFOUND},
+	}
+	assert.Equal(t, EINTERNAL, ErrorCode(error))
+	assert.Equal(t, http.StatusInternalServerError, ErrorStatusCode(error))
+	assert.Equal(t, "mailer is down", ErrorMessage(error))
+
+	// Test with joined error wrapped in Error
+	wrapped := &Error{Op: "user.Create", Err: joinError{nil, &Error{Code: ENOTFOUND}, &Error{Code: EINVALID}}}
+	assert.Equal(t, ENOTFOUND, ErrorCode(wrapped))
+	assert.Equal(t, http.StatusNotFound, ErrorStatusCode(wrapped))
+
+	// Test with normal errors
+	assert.Equal(t, EINTERNAL, ErrorCode(joinError{errors.New("some error")}))
+}
+
+func TestFormatErrorExpandJoined(t *testing.T) {
+	error := &Error{
+		Op: "user.Create",
+		Err: joinError{
+			&Error{Code: EINVALID, Message: "email is invalid"},
+			&Error{Code: ECONFLICT},
+		},
+	}
+
+	// Test without expansion
+	actual := FormatError(error)
+	assert.Equal(t, ECONFLICT, actual.Code)
+	assert.Nil(t, actual.Details)
+
+	// Test with expansion
+	defer func() { ExpandJoined = false }()
+	ExpandJoined = true
+	actual = FormatError(error)
+	expected := []JSONError{
+		FormatError(&Error{Code: EINVALID, Message: "email is invalid"}),
+		FormatError(&Error{Code: ECONFLICT}),
+	}
+	assert.Equal(t, expected, actual.Details["errors"])
+}
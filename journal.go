@@ -0,0 +1,104 @@
+package ergo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Journal is a Reporter appending the handled errors as newline delimited Json to a file.
+// The file is rotated, renaming it with a timestamp suffix, when it exceeds MaxSize bytes
+// or is older than MaxAge, from its modification time when opened; zero values disable
+// the respective rotation. The failures to rotate the file are sent to OnDiagnostic and
+// the journal keeps appending to it. It is safe for concurrent use.
+type Journal struct {
+	MaxSize int64
+	MaxAge  time.Duration
+
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// OpenJournal opens, or creates, the journal file at path
+func OpenJournal(path string, maxSize int64, maxAge time.Duration) (*Journal, error) {
+	j := &Journal{MaxSize: maxSize, MaxAge: maxAge, path: path}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Report appends the event of the error to the journal.
+// Write failures are ignored, so that the journal never fails the request.
+func (j *Journal) Report(err error) {
+	line, marshalErr := json.Marshal(NewEvent(err))
+	if marshalErr != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return
+	}
+	if j.needsRotation(int64(len(line))) {
+		if rotateErr := j.rotate(); rotateErr != nil {
+			return
+		}
+	}
+	n, _ := j.file.Write(line)
+	j.size += int64(n)
+}
+
+// Close closes the journal file
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+func (j *Journal) needsRotation(size int64) bool {
+	if j.size == 0 {
+		return false
+	}
+	return (j.MaxSize > 0 && j.size+size > j.MaxSize) ||
+		(j.MaxAge > 0 && now().Sub(j.openedAt) > j.MaxAge)
+}
+
+func (j *Journal) rotate() error {
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+	j.file = nil
+	rotated := fmt.Sprintf("%s.%s", j.path, now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(j.path, rotated); err != nil {
+		// Reopen the original file, so that the following reports are not lost
+		OnDiagnostic(&Error{Code: EINTERNAL, Op: "ergo.Journal", Err: err})
+	}
+	return j.open()
+}
+
+func (j *Journal) open() error {
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	j.file, j.size, j.openedAt = file, info.Size(), info.ModTime()
+	return nil
+}
@@ -0,0 +1,139 @@
+package ergo
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ergo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "errors.ndjson")
+	journal, err := OpenJournal(path, 0, 0)
+	assert.NoError(t, err)
+	journal.Report(&Error{Code: ENOTFOUND, Op: "user.Get"})
+	journal.Report(&Error{Code: EINVALID})
+	assert.NoError(t, journal.Close())
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+
+	var event Event
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+	assert.Equal(t, ENOTFOUND, event.Code)
+	assert.Equal(t, "user.Get", event.Op)
+
+	// Test that reporting after Close is ignored
+	journal.Report(&Error{Code: EINVALID})
+}
+
+func TestJournalRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ergo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "errors.ndjson")
+	journal, err := OpenJournal(path, 10, 0)
+	assert.NoError(t, err)
+	defer journal.Close()
+
+	journal.Report(&Error{Code: ENOTFOUND})
+	journal.Report(&Error{Code: EINVALID})
+	journal.Report(&Error{Code: ECONFLICT})
+
+	files, err := filepath.Glob(path + "*")
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+}
+
+// stepClock is a Clock whose time is moved by the tests
+type stepClock struct {
+	now time.Time
+}
+
+func (c *stepClock) Now() time.Time {
+	return c.now
+}
+
+func TestJournalAgeRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ergo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer func() {
+		DefaultClock = systemClock{}
+	}()
+	clock := &stepClock{now: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	DefaultClock = clock
+
+	// The age of the file is its modification time
+	path := filepath.Join(dir, "errors.ndjson")
+	assert.NoError(t, ioutil.WriteFile(path, nil, 0644))
+	assert.NoError(t, os.Chtimes(path, clock.now, clock.now))
+	journal, err := OpenJournal(path, 0, time.Hour)
+	assert.NoError(t, err)
+	defer journal.Close()
+
+	journal.Report(&Error{Code: ENOTFOUND})
+	clock.now = clock.now.Add(30 * time.Minute)
+	journal.Report(&Error{Code: EINVALID})
+	clock.now = clock.now.Add(time.Hour)
+	journal.Report(&Error{Code: ECONFLICT})
+
+	files, err := filepath.Glob(path + "*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{path, path + ".20240301T133000.000000000"}, files)
+
+	// Test with a file older than MaxAge when opened
+	old := filepath.Join(dir, "old.ndjson")
+	assert.NoError(t, ioutil.WriteFile(old, []byte("{}\n"), 0644))
+	assert.NoError(t, os.Chtimes(old, clock.now.Add(-2*time.Hour), clock.now.Add(-2*time.Hour)))
+	oldJournal, err := OpenJournal(old, 0, time.Hour)
+	assert.NoError(t, err)
+	defer oldJournal.Close()
+	oldJournal.Report(&Error{Code: ENOTFOUND})
+	files, err = filepath.Glob(old + "*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{old, old + ".20240301T133000.000000000"}, files)
+}
+
+func TestJournalRotationFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ergo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	var diagnostics []error
+	defer func() {
+		DefaultClock = systemClock{}
+		OnDiagnostic = func(diagnostic error) {}
+	}()
+	DefaultClock = &stepClock{now: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	OnDiagnostic = func(diagnostic error) { diagnostics = append(diagnostics, diagnostic) }
+
+	// The rotated file cannot replace a non-empty directory
+	path := filepath.Join(dir, "errors.ndjson")
+	rotated := path + ".20240301T120000.000000000"
+	assert.NoError(t, os.MkdirAll(filepath.Join(rotated, "taken"), 0755))
+	journal, err := OpenJournal(path, 10, 0)
+	assert.NoError(t, err)
+	defer journal.Close()
+
+	journal.Report(&Error{Code: ENOTFOUND})
+	journal.Report(&Error{Code: EINVALID})
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, "ergo.Journal", diagnostics[0].(*Error).Op)
+
+	// Test that the journal keeps appending to the original file
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+}
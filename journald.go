@@ -0,0 +1,76 @@
+package ergo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JournaldSocket is the socket of the native protocol of systemd-journald
+const JournaldSocket = "/run/systemd/journal/socket"
+
+// Journald is a Reporter sending the handled errors to systemd-journald with the
+// native protocol, with code, error_id, op, status and cause as ERGO_* fields.
+// It is safe for concurrent use.
+type Journald struct {
+	Writer     io.Writer
+	Identifier string
+
+	mu sync.Mutex
+}
+
+// DialJournald returns a Journald connected to the socket of systemd-journald
+func DialJournald(identifier string) (*Journald, error) {
+	conn, err := net.Dial("unixgram", JournaldSocket)
+	if err != nil {
+		return nil, err
+	}
+	return &Journald{Writer: conn, Identifier: identifier}, nil
+}
+
+// Report sends the fields of the error as a single datagram
+func (j *Journald) Report(err error) {
+	priority := "4"
+	if ErrorStatusCode(err) >= http.StatusInternalServerError {
+		priority = "3"
+	}
+
+	var buffer bytes.Buffer
+	writeJournaldField(&buffer, "MESSAGE", err.Error())
+	writeJournaldField(&buffer, "PRIORITY", priority)
+	if j.Identifier != "" {
+		writeJournaldField(&buffer, "SYSLOG_IDENTIFIER", j.Identifier)
+	}
+	writeJournaldField(&buffer, "ERGO_CODE", ErrorCode(err))
+	writeJournaldField(&buffer, "ERGO_ERROR_ID", eventID(err))
+	if op := errorOp(err); op != "" {
+		writeJournaldField(&buffer, "ERGO_OP", op)
+	}
+	writeJournaldField(&buffer, "ERGO_STATUS", strconv.Itoa(ErrorStatusCode(err)))
+	writeJournaldField(&buffer, "ERGO_CAUSE", ErrorCause(err))
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, _ = j.Writer.Write(buffer.Bytes())
+}
+
+// writeJournaldField writes a field in the native protocol format,
+// using the binary-safe encoding for values containing newlines
+func writeJournaldField(buffer *bytes.Buffer, key string, value string) {
+	buffer.WriteString(key)
+	if !strings.Contains(value, "\n") {
+		buffer.WriteByte('=')
+		buffer.WriteString(value)
+		buffer.WriteByte('\n')
+		return
+	}
+	buffer.WriteByte('\n')
+	_ = binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value)
+	buffer.WriteByte('\n')
+}
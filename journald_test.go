@@ -0,0 +1,30 @@
+package ergo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournald(t *testing.T) {
+	var buffer bytes.Buffer
+	journald := &Journald{Writer: &buffer, Identifier: "billing"}
+
+	journald.Report(&Error{Code: EINVALID, Op: "invoice.Create", Message: "amount is required", ErrorID: "err-1"})
+	expected := "MESSAGE=invoice.Create: <invalid>amount is required\n" +
+		"PRIORITY=4\n" +
+		"SYSLOG_IDENTIFIER=billing\n" +
+		"ERGO_CODE=invalid\n" +
+		"ERGO_ERROR_ID=err-1\n" +
+		"ERGO_OP=invoice.Create\n" +
+		"ERGO_STATUS=400\n" +
+		"ERGO_CAUSE=unknown\n"
+	assert.Equal(t, expected, buffer.String())
+
+	// Test with a multiline message
+	buffer.Reset()
+	journald.Report(errors.New("line1\nline2"))
+	assert.Equal(t, "MESSAGE\n\x0b\x00\x00\x00\x00\x00\x00\x00line1\nline2\nPRIORITY=3\n", buffer.String()[:39])
+}
@@ -0,0 +1,97 @@
+// Package lambda converts ergo errors into AWS API Gateway responses for Lambda functions.
+//
+// The responses have the fields of the events.APIGatewayProxyResponse and
+// events.APIGatewayV2HTTPResponse of aws-lambda-go, so they convert to them directly,
+// e.g. events.APIGatewayProxyResponse(response).
+package lambda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skullflow/ergo"
+	"github.com/skullflow/ergo/internal/recorder"
+)
+
+// ProxyResponse is the response of a Lambda function behind an API Gateway REST API
+type ProxyResponse struct {
+	StatusCode        int                 `json:"statusCode"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded,omitempty"`
+}
+
+// HTTPResponse is the response of a Lambda function behind an API Gateway HTTP API (payload format 2.0)
+type HTTPResponse struct {
+	StatusCode        int                 `json:"statusCode"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded,omitempty"`
+	Cookies           []string            `json:"cookies"`
+}
+
+// FormatProxyResponse returns the REST API response of the error, written with
+// ergo.WriteErrorContext, and reports the error. The headers written several times,
+// e.g. Vary, are in the MultiValueHeaders with all their values.
+func FormatProxyResponse(ctx context.Context, err error) ProxyResponse {
+	w := recorder.New()
+	ergo.WriteErrorContext(ctx, w, err)
+	return ProxyResponse{
+		StatusCode:        w.Status,
+		Headers:           w.Headers(),
+		MultiValueHeaders: w.MultiValueHeaders(),
+		Body:              string(w.Body),
+	}
+}
+
+// FormatHTTPResponse returns the HTTP API response of the error, written with
+// ergo.WriteErrorContext, and reports the error. The HTTP APIs have no multi-value
+// headers: the values of the headers written several times are joined with commas.
+func FormatHTTPResponse(ctx context.Context, err error) HTTPResponse {
+	w := recorder.New()
+	ergo.WriteErrorContext(ctx, w, err)
+	return HTTPResponse{
+		StatusCode: w.Status,
+		Headers:    w.Headers(),
+		Body:       string(w.Body),
+	}
+}
+
+// HandleProxy runs the handler of a REST API function, converting the error it returns
+// or its panic into the error response, so that API Gateway never answers with a bare 502.
+func HandleProxy(ctx context.Context, handler func() (ProxyResponse, error)) (response ProxyResponse, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			response = FormatProxyResponse(ctx, panicError(recovered))
+		}
+	}()
+	if response, err = handler(); err != nil {
+		return FormatProxyResponse(ctx, err), nil
+	}
+	return response, nil
+}
+
+// HandleHTTP runs the handler of an HTTP API function, converting the error it returns
+// or its panic into the error response.
+func HandleHTTP(ctx context.Context, handler func() (HTTPResponse, error)) (response HTTPResponse, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			response = FormatHTTPResponse(ctx, panicError(recovered))
+		}
+	}()
+	if response, err = handler(); err != nil {
+		return FormatHTTPResponse(ctx, err), nil
+	}
+	return response, nil
+}
+
+// panicError returns the internal error of a recovered panic
+func panicError(recovered interface{}) error {
+	return &ergo.Error{
+		Code: ergo.EINTERNAL,
+		Op:   "lambda.Handle",
+		Err:  fmt.Errorf("panic: %v", recovered),
+	}
+}
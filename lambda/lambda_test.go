@@ -0,0 +1,59 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skullflow/ergo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatProxyResponse(t *testing.T) {
+	defer func(generator ergo.IDGenerator) { ergo.DefaultIDGenerator = generator }(ergo.DefaultIDGenerator)
+	ergo.DefaultIDGenerator = ergo.IDGeneratorFunc(func() string { return "err-1" })
+
+	response := FormatProxyResponse(context.Background(), &ergo.Error{Code: ergo.ENOTFOUND})
+	assert.Equal(t, 404, response.StatusCode)
+	assert.Equal(t, "application/json; charset=utf-8", response.Headers["Content-Type"])
+	assert.Equal(t, "no-store", response.Headers["Cache-Control"])
+	assert.Equal(t, []string{"no-store"}, response.MultiValueHeaders["Cache-Control"])
+	assert.JSONEq(t, `{"code":"not_found","error_id":"err-1","code_id":3,"status_code":404,"message":"Resource not found."}`, response.Body)
+}
+
+func TestHandleProxy(t *testing.T) {
+	// Test with a successful handler
+	response, err := HandleProxy(context.Background(), func() (ProxyResponse, error) {
+		return ProxyResponse{StatusCode: 200, Body: "ok"}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", response.Body)
+
+	// Test with an error
+	response, err = HandleProxy(context.Background(), func() (ProxyResponse, error) {
+		return ProxyResponse{}, &ergo.Error{Code: ergo.EINVALID}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 400, response.StatusCode)
+
+	// Test with a panic
+	response, err = HandleProxy(context.Background(), func() (ProxyResponse, error) {
+		panic("handler bug")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 500, response.StatusCode)
+	assert.NotContains(t, response.Body, "handler bug")
+}
+
+func TestHandleHTTP(t *testing.T) {
+	response, err := HandleHTTP(context.Background(), func() (HTTPResponse, error) {
+		return HTTPResponse{}, errors.New("some error")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 500, response.StatusCode)
+
+	response, _ = HandleHTTP(context.Background(), func() (HTTPResponse, error) {
+		panic("handler bug")
+	})
+	assert.Equal(t, 500, response.StatusCode)
+}
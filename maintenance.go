@@ -0,0 +1,90 @@
+package ergo
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Unavailable returns an EUNAVAILABLE error with the message, carrying the seconds after
+// which the client can retry under the "retry_after" key of the details, if positive.
+// The writer sends them as the Retry-After header.
+func Unavailable(message string, retryAfter time.Duration) *Error {
+	err := construct(&Error{Code: EUNAVAILABLE, Message: message})
+	if seconds := int64(retryAfter / time.Second); seconds > 0 {
+		err.setDetail("retry_after", seconds)
+	}
+	return err
+}
+
+// setRetryAfterHeader sets the Retry-After header from the details of an unavailable error
+func setRetryAfterHeader(header http.Header, err error) {
+	retryAfter, hasRetryAfter := ErrorDetails(err)["retry_after"].(int64)
+	if ErrorCode(err) != EUNAVAILABLE || !hasRetryAfter {
+		return
+	}
+	header.Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+}
+
+// TagMaintenance tags the errors of the maintenance mode, which are expected: they are
+// sent to SuppressedReporters instead of the Reporters, like the errors of Suppress.
+const TagMaintenance = "maintenance"
+
+// Maintenance is a middleware answering the requests with an EUNAVAILABLE error while it
+// is enabled, except the ones whose path starts with a prefix of Allow, e.g. health checks.
+// The errors are tagged with TagMaintenance, so that they do not flood the Reporters.
+// It is safe for concurrent use.
+type Maintenance struct {
+	Message    string
+	RetryAfter time.Duration
+	Allow      []string
+
+	mu      sync.RWMutex
+	enabled bool
+}
+
+// Enable starts answering the requests with the maintenance error
+func (m *Maintenance) Enable() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.enabled = true
+}
+
+// Disable stops answering the requests with the maintenance error
+func (m *Maintenance) Disable() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.enabled = false
+}
+
+// Enabled reports whether the maintenance mode is enabled
+func (m *Maintenance) Enabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled
+}
+
+// Handler returns the middleware serving the maintenance error in place of next
+func (m *Maintenance) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.Enabled() || m.allowed(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		err := Unavailable(m.Message, m.RetryAfter)
+		err.Tags = append(err.Tags, TagMaintenance)
+		ServeError(w, r, err)
+	})
+}
+
+// allowed reports whether the path is served during the maintenance
+func (m *Maintenance) allowed(path string) bool {
+	for _, prefix := range m.Allow {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package ergo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenance(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	defer func() {
+		Reporters = nil
+		SuppressedReporters = nil
+	}()
+	alerts, debug := &captureReporter{}, &captureReporter{}
+	Reporters = []Reporter{alerts}
+	SuppressedReporters = []Reporter{debug}
+	maintenance := &Maintenance{Message: "Back at 10:00 UTC.", RetryAfter: 10 * time.Minute, Allow: []string{"/health"}}
+	handler := maintenance.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	// Test with the maintenance disabled
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/users", nil))
+	assert.Equal(t, 204, recorder.Code)
+
+	// Test with the maintenance enabled
+	maintenance.Enable()
+	assert.True(t, maintenance.Enabled())
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/users", nil))
+	assert.Equal(t, 503, recorder.Code)
+	assert.Equal(t, "600", recorder.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"code":"unavailable","error_id":"err-1","code_id":15,"status_code":503,"message":"Back at 10:00 UTC.","details":{"retry_after":600}}`, recorder.Body.String())
+
+	// Test that the maintenance errors are not sent to the Reporters
+	assert.Empty(t, alerts.reported())
+	assert.Len(t, debug.reported(), 1)
+	assert.True(t, MatchTag(TagMaintenance)(debug.reported()[0]))
+
+	// Test with an allowed path
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/health/live", nil))
+	assert.Equal(t, 204, recorder.Code)
+
+	maintenance.Disable()
+	assert.False(t, maintenance.Enabled())
+}
+
+func TestUnavailable(t *testing.T) {
+	err := Unavailable("", 0)
+	assert.Equal(t, "Service unavailable.", ErrorMessage(err))
+	assert.Empty(t, ErrorDetails(err))
+
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, err)
+	assert.Empty(t, recorder.Header().Get("Retry-After"))
+}
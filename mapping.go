@@ -0,0 +1,41 @@
+package ergo
+
+// Mapping returns a copy of the registry of the application error codes, i.e. the
+// status, messages, retryability and severity of each code.
+func Mapping() map[string]CodeInfo {
+	codes := policy().Codes
+	mapping := make(map[string]CodeInfo, len(codes))
+	for code, info := range codes {
+		mapping[code] = info
+	}
+	return mapping
+}
+
+// SetMapping replaces the registry of the application error codes with a copy of the
+// mapping, e.g. the company-wide standard mapping. The mapping should define EINTERNAL,
+// which unregistered codes are reported as. The registry is swapped with Update, so an
+// invalid mapping is returned as an error and leaves the registry in place.
+func SetMapping(mapping map[string]CodeInfo) error {
+	codes := make(map[string]CodeInfo, len(mapping))
+	for code, info := range mapping {
+		codes[code] = info
+	}
+	return Update(Config{Codes: codes})
+}
+
+// TagRetryable marks the errors that can be retried as is, whatever their code
+const TagRetryable = "retryable"
+
+// ErrorRetryable reports whether the request that has generated the error can be retried as is,
+// i.e. its code is retryable or it is tagged with TagRetryable.
+func ErrorRetryable(err error) bool {
+	return err != nil && (policy().Codes[ErrorCode(err)].Retryable || MatchTag(TagRetryable)(err))
+}
+
+// ErrorSeverity returns the severity of the error, as defined by the registry for its code
+func ErrorSeverity(err error) string {
+	if err == nil {
+		return ""
+	}
+	return policy().Codes[ErrorCode(err)].Severity
+}
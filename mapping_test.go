@@ -0,0 +1,48 @@
+package ergo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapping(t *testing.T) {
+	mapping := Mapping()
+	assert.Equal(t, Codes, mapping)
+
+	// Test that the mapping is a copy
+	delete(mapping, ENOTFOUND)
+	assert.Contains(t, Codes, ENOTFOUND)
+
+	// Test with a replacement mapping
+	defer resetConfig()
+	err := SetMapping(map[string]CodeInfo{
+		EINTERNAL: {Status: http.StatusInternalServerError, Message: "Something went wrong.", Severity: SeverityError},
+		EINVALID:  {Status: http.StatusUnprocessableEntity, Message: "Invalid request.", Severity: SeverityInfo},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 422, ErrorStatusCode(&Error{Code: EINVALID}))
+	assert.Equal(t, "Invalid request.", ErrorMessage(&Error{Code: EINVALID}))
+	assert.Equal(t, 500, ErrorStatusCode(&Error{Code: ENOTFOUND}))
+	assert.Contains(t, Codes, ENOTFOUND)
+
+	// Test with an invalid mapping, which leaves the registry in place
+	assert.Error(t, SetMapping(map[string]CodeInfo{EINVALID: {Status: 422, Message: "Invalid request."}}))
+	assert.Equal(t, 422, ErrorStatusCode(&Error{Code: EINVALID}))
+}
+
+func TestErrorRetryable(t *testing.T) {
+	assert.False(t, ErrorRetryable(errors.New("some error")))
+	assert.True(t, ErrorRetryable(&Error{Code: EUNAVAILABLE}))
+	assert.False(t, ErrorRetryable(&Error{Code: EINVALID}))
+	assert.False(t, ErrorRetryable(nil))
+}
+
+func TestErrorSeverity(t *testing.T) {
+	assert.Equal(t, SeverityError, ErrorSeverity(errors.New("some error")))
+	assert.Equal(t, SeverityWarning, ErrorSeverity(&Error{Code: EINVALID}))
+	assert.Equal(t, SeverityInfo, ErrorSeverity(&Error{Code: ENOTFOUND}))
+	assert.Equal(t, "", ErrorSeverity(nil))
+}
@@ -0,0 +1,99 @@
+package ergo
+
+import "strings"
+
+// Matcher reports whether an error matches a rule, used to configure
+// the subsystems of the package declaratively.
+type Matcher func(err error) bool
+
+// MatchCode matches the errors with one of the codes
+func MatchCode(codes ...string) Matcher {
+	return func(err error) bool {
+		code := ErrorCode(err)
+		for _, candidate := range codes {
+			if code == candidate {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchOpPrefix matches the errors with an operation of the stack starting with prefix
+func MatchOpPrefix(prefix string) Matcher {
+	return func(err error) bool {
+		for _, op := range errorOps(err) {
+			if strings.HasPrefix(op, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchTag matches the errors tagged with tag
+func MatchTag(tag string) Matcher {
+	return func(err error) bool {
+		for _, candidate := range ErrorTags(err) {
+			if candidate == tag {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// And matches the errors matching all the matchers
+func And(matchers ...Matcher) Matcher {
+	return func(err error) bool {
+		for _, match := range matchers {
+			if !match(err) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches the errors matching at least one of the matchers
+func Or(matchers ...Matcher) Matcher {
+	return func(err error) bool {
+		for _, match := range matchers {
+			if match(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches the errors not matching the matcher
+func Not(matcher Matcher) Matcher {
+	return func(err error) bool {
+		return !matcher(err)
+	}
+}
+
+// ErrorTags returns the tags of all the errors of the stack, outermost first
+func ErrorTags(err error) []string {
+	e, isCustomError := err.(*Error)
+	if !isCustomError {
+		return nil
+	}
+	inner := ErrorTags(e.Err)
+	if len(e.Tags) == 0 {
+		return inner
+	}
+	return append(append([]string{}, e.Tags...), inner...)
+}
+
+// errorOps returns the operations of all the errors of the stack, outermost first
+func errorOps(err error) []string {
+	var ops []string
+	for e, isCustomError := err.(*Error); isCustomError; e, isCustomError = e.Err.(*Error) {
+		if e.Op != "" {
+			ops = append(ops, e.Op)
+		}
+	}
+	return ops
+}
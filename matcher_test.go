@@ -0,0 +1,45 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchCode(t *testing.T) {
+	match := MatchCode(ENOTFOUND, ECONFLICT)
+	assert.True(t, match(&Error{Code: ECONFLICT}))
+	assert.False(t, match(&Error{Code: EINVALID}))
+	assert.False(t, match(errors.New("some error")))
+}
+
+func TestMatchOpPrefix(t *testing.T) {
+	match := MatchOpPrefix("billing.")
+	assert.True(t, match(&Error{Op: "http.Handle", Err: &Error{Op: "billing.Charge"}}))
+	assert.False(t, match(&Error{Op: "user.Get"}))
+	assert.False(t, match(errors.New("some error")))
+}
+
+func TestMatchTag(t *testing.T) {
+	match := MatchTag("critical")
+	assert.True(t, match(&Error{Tags: []string{"billing"}, Err: &Error{Tags: []string{"critical"}}}))
+	assert.False(t, match(&Error{Tags: []string{"billing"}}))
+}
+
+func TestMatcherCombinators(t *testing.T) {
+	error := &Error{Code: EINTERNAL, Op: "billing.Charge"}
+
+	assert.True(t, And(MatchCode(EINTERNAL), MatchOpPrefix("billing."))(error))
+	assert.False(t, And(MatchCode(EINTERNAL), MatchOpPrefix("user."))(error))
+	assert.True(t, Or(MatchCode(ENOTFOUND), MatchOpPrefix("billing."))(error))
+	assert.False(t, Or(MatchCode(ENOTFOUND), MatchOpPrefix("user."))(error))
+	assert.False(t, Not(MatchCode(EINTERNAL))(error))
+}
+
+func TestErrorTags(t *testing.T) {
+	assert.Nil(t, ErrorTags(errors.New("some error")))
+
+	error := &Error{Tags: []string{"billing"}, Err: &Error{Err: &Error{Tags: []string{"critical"}}}}
+	assert.Equal(t, []string{"billing", "critical"}, ErrorTags(error))
+}
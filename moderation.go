@@ -0,0 +1,72 @@
+package ergo
+
+import "sort"
+
+// Categories of the policy violations of the content
+const (
+	ViolationSpam         = "spam"
+	ViolationHarassment   = "harassment"
+	ViolationHate         = "hate"
+	ViolationAdult        = "adult"
+	ViolationViolence     = "violence"
+	ViolationIllegal      = "illegal"
+	ViolationPersonalData = "personal_data"
+)
+
+// Verdicts of the moderation of an item of the content
+const (
+	VerdictAllowed  = "allowed"
+	VerdictRejected = "rejected"
+	VerdictReview   = "review"
+)
+
+// ItemVerdict is the verdict of the moderation of an item of the content, e.g. the
+// title or an image, with the categories of the policies it violates
+type ItemVerdict struct {
+	Item       string   `json:"item"`
+	Verdict    string   `json:"verdict"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// PolicyViolation returns an EPOLICYVIOLATION error for content rejected by the moderation,
+// carrying the verdicts of the items under the "verdicts" key of the details and the
+// categories of the rejected items, sorted, under the "categories" key, so that the
+// clients can point the user to the items to change.
+func PolicyViolation(verdicts ...ItemVerdict) *Error {
+	return construct(&Error{
+		Code: EPOLICYVIOLATION,
+		Details: map[string]interface{}{
+			"categories": violatedCategories(verdicts),
+			"verdicts":   verdicts,
+		},
+	})
+}
+
+// PostingRestricted returns an EFORBIDDEN error for an author who may not publish content
+// because of past violations of the policies of the categories
+func PostingRestricted(categories ...string) *Error {
+	return construct(&Error{
+		Code:    EFORBIDDEN,
+		Message: "Posting is restricted for policy violations.",
+		Details: map[string]interface{}{"categories": categories},
+	})
+}
+
+// violatedCategories returns the distinct categories of the rejected items, sorted
+func violatedCategories(verdicts []ItemVerdict) []string {
+	seen := make(map[string]bool)
+	categories := []string{}
+	for _, verdict := range verdicts {
+		if verdict.Verdict != VerdictRejected {
+			continue
+		}
+		for _, category := range verdict.Categories {
+			if !seen[category] {
+				seen[category] = true
+				categories = append(categories, category)
+			}
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
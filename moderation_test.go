@@ -0,0 +1,30 @@
+package ergo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyViolation(t *testing.T) {
+	verdicts := []ItemVerdict{
+		{Item: "title", Verdict: VerdictAllowed},
+		{Item: "body", Verdict: VerdictRejected, Categories: []string{ViolationSpam, ViolationHarassment}},
+		{Item: "images[0]", Verdict: VerdictRejected, Categories: []string{ViolationSpam}},
+		{Item: "images[1]", Verdict: VerdictReview, Categories: []string{ViolationAdult}},
+	}
+	err := PolicyViolation(verdicts...)
+
+	jsonError := FormatError(err)
+	assert.Equal(t, http.StatusUnprocessableEntity, jsonError.StatusCode)
+	assert.Equal(t, EPOLICYVIOLATION, jsonError.Code)
+	assert.Equal(t, "The content violates our policies.", jsonError.Message)
+	assert.Equal(t, []string{ViolationHarassment, ViolationSpam}, jsonError.Details["categories"])
+	assert.Equal(t, verdicts, jsonError.Details["verdicts"])
+
+	// Test with a restricted author
+	err = PostingRestricted(ViolationHate)
+	assert.Equal(t, http.StatusForbidden, ErrorStatusCode(err))
+	assert.Equal(t, []string{ViolationHate}, err.Details["categories"])
+}
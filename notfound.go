@@ -0,0 +1,44 @@
+package ergo
+
+import "time"
+
+// Suggestion defines an alternative to a resource that was not found
+// Value is the "did you mean" value, e.g. an identifier or a search term
+// Link is the location of the alternative resource
+type Suggestion struct {
+	Value string `json:"value"`
+	Link  string `json:"link,omitempty"`
+}
+
+// NotFound returns an ENOTFOUND error for the resource, carrying the suggestions
+// under the "suggestions" key of the details.
+func NotFound(resourceType string, resourceID string, suggestions ...Suggestion) *Error {
+	err := construct(&Error{Code: ENOTFOUND})
+	err.SetResource(resourceType, resourceID)
+	if len(suggestions) > 0 {
+		err.setDetail("suggestions", suggestions)
+	}
+	return err
+}
+
+// Gone returns an EGONE error for the resource deleted at deletedAt, carrying the deletion time
+// under the "deleted_at" key of the details and, if not empty, the link restoring the resource
+// under the "undo" key.
+func Gone(resourceType string, resourceID string, deletedAt time.Time, undo string) *Error {
+	err := construct(&Error{Code: EGONE})
+	err.SetResource(resourceType, resourceID)
+	err.setDetail("deleted_at", deletedAt.UTC().Format(time.RFC3339))
+	if undo != "" {
+		err.setDetail("undo", undo)
+	}
+	return err
+}
+
+// Missing returns the error of a resource that cannot be found: an ENOTFOUND error if it
+// never existed, i.e. deletedAt is zero, or an EGONE error if it has been deleted.
+func Missing(resourceType string, resourceID string, deletedAt time.Time, undo string) *Error {
+	if deletedAt.IsZero() {
+		return NotFound(resourceType, resourceID)
+	}
+	return Gone(resourceType, resourceID, deletedAt, undo)
+}
@@ -0,0 +1,47 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFound(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	err := NotFound("user", "jdoe")
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	assert.Equal(t, "user", ErrorResourceType(err))
+	assert.Equal(t, "jdoe", ErrorResourceID(err))
+	assert.NotContains(t, ErrorDetails(err), "suggestions")
+
+	// Test with suggestions
+	err = NotFound("user", "jdoe", Suggestion{Value: "j.doe", Link: "/users/j.doe"}, Suggestion{Value: "jdoe2"})
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, err)
+	assert.JSONEq(t, `{"code":"not_found","error_id":"err-1","code_id":3,"status_code":404,"message":"Resource not found.","details":{`+
+		`"resource_type":"user","resource_id":"jdoe","suggestions":[{"value":"j.doe","link":"/users/j.doe"},{"value":"jdoe2"}]}}`,
+		recorder.Body.String())
+}
+
+func TestMissing(t *testing.T) {
+	// Test with a resource that never existed
+	err := Missing("user", "jdoe", time.Time{}, "")
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+
+	// Test with a deleted resource
+	err = Missing("user", "jdoe", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), "/users/jdoe/restore")
+	assert.Equal(t, EGONE, ErrorCode(err))
+	assert.Equal(t, 410, ErrorStatusCode(err))
+	assert.Equal(t, "Resource has been deleted.", ErrorMessage(err))
+	assert.Equal(t, map[string]interface{}{
+		"resource_type": "user",
+		"resource_id":   "jdoe",
+		"deleted_at":    "2024-03-01T12:00:00Z",
+		"undo":          "/users/jdoe/restore",
+	}, ErrorDetails(err))
+
+	// Test without undo
+	assert.NotContains(t, ErrorDetails(Gone("user", "jdoe", time.Now(), "")), "undo")
+}
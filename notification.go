@@ -0,0 +1,132 @@
+package ergo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EventSummary defines the handled errors sharing a fingerprint
+type EventSummary struct {
+	Fingerprint string
+	Code        string
+	Op          string
+	StatusCode  int
+	Message     string
+	Count       int
+}
+
+// SummarizeEvents groups the events by fingerprint, the most frequent first
+func SummarizeEvents(events []WebhookEvent) []EventSummary {
+	indexes := make(map[string]int)
+	var summaries []EventSummary
+	for _, event := range events {
+		index, ok := indexes[event.Fingerprint]
+		if !ok {
+			index = len(summaries)
+			indexes[event.Fingerprint] = index
+			summaries = append(summaries, EventSummary{
+				Fingerprint: event.Fingerprint,
+				Code:        event.Code,
+				Op:          event.Op,
+				StatusCode:  event.StatusCode,
+				Message:     event.Message,
+			})
+		}
+		summaries[index].Count++
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Count > summaries[j].Count
+	})
+	return summaries
+}
+
+// SlackEncoder returns a Webhook encoder producing Slack Block Kit messages.
+// linkBase, if not empty, is prefixed to the fingerprints to link them.
+func SlackEncoder(linkBase string) func(events []WebhookEvent) ([]byte, error) {
+	return func(events []WebhookEvent) ([]byte, error) {
+		title := notificationTitle(events)
+		blocks := []interface{}{
+			map[string]interface{}{
+				"type": "header",
+				"text": map[string]interface{}{"type": "plain_text", "text": title},
+			},
+		}
+		for _, summary := range SummarizeEvents(events) {
+			fingerprint := "`" + summary.Fingerprint + "`"
+			if linkBase != "" {
+				fingerprint = fmt.Sprintf("<%s%s|%s>", linkBase, summary.Fingerprint, summary.Fingerprint)
+			}
+			blocks = append(blocks, map[string]interface{}{
+				"type": "section",
+				"text": map[string]interface{}{
+					"type": "mrkdwn",
+					"text": fmt.Sprintf("*%s* (%d) `%s` ×%d\n%s\n%s", summary.Code, summary.StatusCode, notificationOp(summary.Op), summary.Count, summary.Message, fingerprint),
+				},
+			})
+		}
+		return json.Marshal(map[string]interface{}{
+			"text":   title,
+			"blocks": blocks,
+		})
+	}
+}
+
+// TeamsEncoder returns a Webhook encoder producing Microsoft Teams Adaptive Card messages.
+// linkBase, if not empty, is prefixed to the fingerprints to link them.
+func TeamsEncoder(linkBase string) func(events []WebhookEvent) ([]byte, error) {
+	return func(events []WebhookEvent) ([]byte, error) {
+		body := []interface{}{
+			map[string]interface{}{
+				"type":   "TextBlock",
+				"size":   "Large",
+				"weight": "Bolder",
+				"text":   notificationTitle(events),
+			},
+		}
+		for _, summary := range SummarizeEvents(events) {
+			fingerprint := summary.Fingerprint
+			if linkBase != "" {
+				fingerprint = fmt.Sprintf("[%s](%s%s)", summary.Fingerprint, linkBase, summary.Fingerprint)
+			}
+			body = append(body, map[string]interface{}{
+				"type": "FactSet",
+				"facts": []interface{}{
+					map[string]interface{}{"title": "Code", "value": fmt.Sprintf("%s (%d)", summary.Code, summary.StatusCode)},
+					map[string]interface{}{"title": "Op", "value": notificationOp(summary.Op)},
+					map[string]interface{}{"title": "Count", "value": fmt.Sprint(summary.Count)},
+					map[string]interface{}{"title": "Fingerprint", "value": fingerprint},
+				},
+			})
+		}
+		return json.Marshal(map[string]interface{}{
+			"type": "message",
+			"attachments": []interface{}{
+				map[string]interface{}{
+					"contentType": "application/vnd.microsoft.card.adaptive",
+					"content": map[string]interface{}{
+						"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+						"type":    "AdaptiveCard",
+						"version": "1.4",
+						"body":    body,
+					},
+				},
+			},
+		})
+	}
+}
+
+func notificationTitle(events []WebhookEvent) string {
+	if len(events) == 1 {
+		return "1 error reported"
+	}
+	return fmt.Sprintf("%d errors reported", len(events))
+}
+
+func notificationOp(op string) string {
+	if strings.TrimSpace(op) == "" {
+		return "-"
+	}
+	return op
+}
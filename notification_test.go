@@ -0,0 +1,72 @@
+package ergo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func notificationEvents() []WebhookEvent {
+	notFound := WebhookEvent{Op: "user.Get", Fingerprint: "f1", JSONError: FormatError(&Error{Code: ENOTFOUND})}
+	internal := WebhookEvent{Fingerprint: "f2", JSONError: FormatError(&Error{Code: EINTERNAL})}
+	return []WebhookEvent{internal, notFound, notFound}
+}
+
+func TestSummarizeEvents(t *testing.T) {
+	summaries := SummarizeEvents(notificationEvents())
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, EventSummary{
+		Fingerprint: "f1",
+		Code:        ENOTFOUND,
+		Op:          "user.Get",
+		StatusCode:  404,
+		Message:     "Resource not found.",
+		Count:       2,
+	}, summaries[0])
+	assert.Equal(t, 1, summaries[1].Count)
+}
+
+func TestSlackEncoder(t *testing.T) {
+	body, err := SlackEncoder("https://errors.example.com/")(notificationEvents())
+	assert.NoError(t, err)
+
+	var message struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Type string `json:"type"`
+			Text struct {
+				Text string `json:"text"`
+			} `json:"text"`
+		} `json:"blocks"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &message))
+	assert.Equal(t, "3 errors reported", message.Text)
+	assert.Len(t, message.Blocks, 3)
+	assert.Equal(t, "header", message.Blocks[0].Type)
+	assert.Equal(t, "*not_found* (404) `user.Get` ×2\nResource not found.\n<https://errors.example.com/f1|f1>", message.Blocks[1].Text.Text)
+	assert.Contains(t, message.Blocks[2].Text.Text, "`-` ×1")
+}
+
+func TestTeamsEncoder(t *testing.T) {
+	body, err := TeamsEncoder("")(notificationEvents()[:1])
+	assert.NoError(t, err)
+
+	var message struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type string                   `json:"type"`
+				Body []map[string]interface{} `json:"body"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &message))
+	assert.Equal(t, "message", message.Type)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", message.Attachments[0].ContentType)
+	card := message.Attachments[0].Content
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	assert.Equal(t, "1 error reported", card.Body[0]["text"])
+	assert.Equal(t, "FactSet", card.Body[1]["type"])
+}
@@ -0,0 +1,68 @@
+package ergo
+
+import "net/http"
+
+// OAuth2 error codes, as defined in RFC 6749 section 5.2
+const (
+	OAuthInvalidRequest       = "invalid_request"
+	OAuthInvalidClient        = "invalid_client"
+	OAuthInvalidGrant         = "invalid_grant"
+	OAuthUnauthorizedClient   = "unauthorized_client"
+	OAuthUnsupportedGrantType = "unsupported_grant_type"
+	OAuthInvalidScope         = "invalid_scope"
+	OAuthAccessDenied         = "access_denied"
+	OAuthServerError          = "server_error"
+)
+
+// OAuthErrorCodes maps the application error codes to the OAuth2 error codes.
+// Codes not present in the map are reported as server_error.
+var OAuthErrorCodes = map[string]string{
+	ECONFLICT:     OAuthInvalidGrant,
+	EINTERNAL:     OAuthServerError,
+	EINVALID:      OAuthInvalidRequest,
+	ENOTFOUND:     OAuthInvalidGrant,
+	EUNAUTHORIZED: OAuthInvalidClient,
+	EFORBIDDEN:    OAuthAccessDenied,
+}
+
+// OAuthError defines the error to send to OAuth2 / OpenID Connect clients
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OAuthErrorCode returns the OAuth2 error code of the root error.
+// Otherwise returns server_error.
+func OAuthErrorCode(err error) string {
+	if code, ok := OAuthErrorCodes[ErrorCode(err)]; ok {
+		return code
+	}
+	return OAuthServerError
+}
+
+// OAuthErrorStatusCode returns the status code of the http request as
+// required by RFC 6749: 401 for invalid_client, 400 for the other client
+// errors and the application status code for server errors.
+func OAuthErrorStatusCode(err error) int {
+	switch OAuthErrorCode(err) {
+	case OAuthServerError:
+		return ErrorStatusCode(err)
+	case OAuthInvalidClient:
+		return http.StatusUnauthorized
+	}
+	return http.StatusBadRequest
+}
+
+// FormatOAuthError will return a Json to be sent to the client describing the error
+// in the RFC 6749 format
+func FormatOAuthError(err error) OAuthError {
+	return OAuthError{
+		Error:            OAuthErrorCode(err),
+		ErrorDescription: ErrorMessage(err),
+	}
+}
+
+// HandleOAuthError will return the RFC 6749 Json representation of the error
+func HandleOAuthError(err error) (int, OAuthError) {
+	return OAuthErrorStatusCode(err), FormatOAuthError(err)
+}
@@ -0,0 +1,50 @@
+package ergo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuthErrorCode(t *testing.T) {
+	// Test with normal error
+	actual := OAuthErrorCode(errors.New("some error"))
+	assert.Equal(t, OAuthServerError, actual)
+
+	// Test with mapped Code
+	actual = OAuthErrorCode(&Error{Code: EINVALID})
+	assert.Equal(t, OAuthInvalidRequest, actual)
+	actual = OAuthErrorCode(&Error{Code: EUNAUTHORIZED})
+	assert.Equal(t, OAuthInvalidClient, actual)
+
+	// Test with unknown Code
+	actual = OAuthErrorCode(&Error{Code: "unknown"})
+	assert.Equal(t, OAuthServerError, actual)
+}
+
+func TestOAuthErrorStatusCode(t *testing.T) {
+	actual := OAuthErrorStatusCode(&Error{Code: ENOTFOUND})
+	assert.Equal(t, http.StatusBadRequest, actual)
+
+	actual = OAuthErrorStatusCode(&Error{Code: EUNAUTHORIZED})
+	assert.Equal(t, http.StatusUnauthorized, actual)
+
+	actual = OAuthErrorStatusCode(errors.New("some error"))
+	assert.Equal(t, http.StatusInternalServerError, actual)
+}
+
+func TestHandleOAuthError(t *testing.T) {
+	error := &Error{
+		Code:    ENOTFOUND,
+		Message: "authorization code expired",
+	}
+	expectedJsonError := OAuthError{
+		Error:            OAuthInvalidGrant,
+		ErrorDescription: "authorization code expired",
+	}
+	actualHttpStatus, actualJsonError := HandleOAuthError(error)
+	assert.Equal(t, http.StatusBadRequest, actualHttpStatus)
+	assert.Equal(t, expectedJsonError, actualJsonError)
+}
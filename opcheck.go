@@ -0,0 +1,44 @@
+package ergo
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// OpPattern is the pattern the logical operations must match, e.g. ^[a-z]+\.[A-Z][A-Za-z]+$.
+// Operations are not validated when it is nil.
+var OpPattern *regexp.Regexp
+
+// StrictOps makes the constructors of the package panic when an operation does not
+// match OpPattern. It is meant to be enabled in development and tests.
+var StrictOps = false
+
+// ValidateOp returns an error if the operation does not match OpPattern
+func ValidateOp(op string) error {
+	if OpPattern == nil || op == "" || OpPattern.MatchString(op) {
+		return nil
+	}
+	return fmt.Errorf("ergo: op %q does not match %s", op, OpPattern)
+}
+
+// ValidateOps returns an error for the first operation of the stack not matching OpPattern
+func ValidateOps(err error) error {
+	e, isCustomError := err.(*Error)
+	if !isCustomError {
+		return nil
+	}
+	if opErr := ValidateOp(e.Op); opErr != nil {
+		return opErr
+	}
+	return ValidateOps(e.Err)
+}
+
+// checkOp panics if StrictOps is enabled and the operation is not valid
+func checkOp(op string) {
+	if !StrictOps {
+		return
+	}
+	if err := ValidateOp(op); err != nil {
+		panic(err)
+	}
+}
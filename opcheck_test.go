@@ -0,0 +1,44 @@
+package ergo
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOp(t *testing.T) {
+	// Test without OpPattern
+	assert.NoError(t, ValidateOp("anything goes"))
+
+	defer func() { OpPattern = nil }()
+	OpPattern = regexp.MustCompile(`^[a-z]+\.[A-Z][A-Za-z]+$`)
+	assert.NoError(t, ValidateOp("user.Create"))
+	assert.NoError(t, ValidateOp(""))
+	assert.EqualError(t, ValidateOp("User_create"), `ergo: op "User_create" does not match ^[a-z]+\.[A-Z][A-Za-z]+$`)
+}
+
+func TestValidateOps(t *testing.T) {
+	defer func() { OpPattern = nil }()
+	OpPattern = regexp.MustCompile(`^[a-z]+\.[A-Z][A-Za-z]+$`)
+
+	assert.NoError(t, ValidateOps(errors.New("some error")))
+	assert.NoError(t, ValidateOps(&Error{Op: "user.Create", Err: &Error{Op: "db.Insert"}}))
+	assert.Error(t, ValidateOps(&Error{Op: "user.Create", Err: &Error{Op: "db_insert"}}))
+}
+
+func TestStrictOps(t *testing.T) {
+	defer func() {
+		OpPattern = nil
+		StrictOps = false
+	}()
+	OpPattern = regexp.MustCompile(`^[a-z]+\.[A-Z][A-Za-z]+$`)
+
+	// Test without strict mode
+	assert.NotPanics(t, func() { _ = Trace(errors.New("some error")) })
+
+	// Test with strict mode, the op of the closure does not match
+	StrictOps = true
+	assert.Panics(t, func() { _ = Trace(errors.New("some error")) })
+}
@@ -0,0 +1,44 @@
+package ergo
+
+import "context"
+
+// Operation is the status resource of a long-running operation, carrying the
+// error envelope of the operation once it has failed.
+type Operation struct {
+	ID     string      `json:"id"`
+	Done   bool        `json:"done"`
+	Error  *JSONError  `json:"error,omitempty"`
+	Result interface{} `json:"result,omitempty"`
+}
+
+// Fail marks the operation as done with the error formatted with FormatErrorContext,
+// and reports the error.
+func (o *Operation) Fail(ctx context.Context, err error) {
+	err = Identify(err)
+	report(err)
+	jsonError := FormatErrorContext(ctx, err)
+	o.Done = true
+	o.Error = &jsonError
+	o.Result = nil
+}
+
+// Complete marks the operation as done with the result
+func (o *Operation) Complete(result interface{}) {
+	o.Done = true
+	o.Error = nil
+	o.Result = result
+}
+
+// Err returns the error of the failed operation, or nil, e.g. for clients polling the status.
+func (o Operation) Err() error {
+	if o.Error == nil {
+		return nil
+	}
+	return &Error{
+		Code:    o.Error.Code,
+		Message: o.Error.Message,
+		Details: o.Error.Details,
+		Hops:    o.Error.Hops,
+		ErrorID: o.Error.ErrorID,
+	}
+}
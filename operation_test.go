@@ -0,0 +1,39 @@
+package ergo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperation(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	capture := &captureReporter{}
+	defer func() { Reporters = nil }()
+	Reporters = []Reporter{capture}
+
+	// Test with a pending operation
+	operation := Operation{ID: "op-1"}
+	body, _ := json.Marshal(operation)
+	assert.JSONEq(t, `{"id":"op-1","done":false}`, string(body))
+	assert.Nil(t, operation.Err())
+
+	// Test with a failed operation
+	err := &Error{Code: ECONFLICT, Message: "Already imported."}
+	operation.Fail(context.Background(), err)
+	body, _ = json.Marshal(operation)
+	assert.JSONEq(t, `{"id":"op-1","done":true,"error":{"code":"conflict","error_id":"err-1","code_id":4,"status_code":409,"message":"Already imported."}}`, string(body))
+	assert.Equal(t, []error{&Error{Err: err, ErrorID: "err-1"}}, capture.reported())
+
+	var polled Operation
+	_ = json.Unmarshal(body, &polled)
+	assert.Equal(t, ECONFLICT, ErrorCode(polled.Err()))
+	assert.Equal(t, "Already imported.", ErrorMessage(polled.Err()))
+
+	// Test with a completed operation
+	operation.Complete("done")
+	assert.Nil(t, operation.Err())
+	assert.Equal(t, "done", operation.Result)
+}
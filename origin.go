@@ -0,0 +1,21 @@
+package ergo
+
+// Origin identifies the service that produced an error
+type Origin struct {
+	Service  string `json:"service"`
+	Version  string `json:"version,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// ServiceOrigin identifies the running service in the errors it formats.
+// The origin is omitted when Service is empty.
+var ServiceOrigin Origin
+
+// serviceOrigin returns the configured origin, or nil if not configured
+func serviceOrigin() *Origin {
+	if ServiceOrigin.Service == "" {
+		return nil
+	}
+	origin := ServiceOrigin
+	return &origin
+}
@@ -0,0 +1,28 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatErrorOrigin(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	// Test without ServiceOrigin
+	actual := FormatError(&Error{Code: ENOTFOUND})
+	assert.Nil(t, actual.Origin)
+
+	defer func() { ServiceOrigin = Origin{} }()
+	ServiceOrigin = Origin{Service: "billing", Version: "1.4.2", Instance: "billing-7f9c"}
+	actual = FormatError(&Error{Code: ENOTFOUND})
+	assert.Equal(t, &Origin{Service: "billing", Version: "1.4.2", Instance: "billing-7f9c"}, actual.Origin)
+
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, &Error{Code: EINVALID})
+	assert.JSONEq(t, `{"code":"invalid","error_id":"err-1","code_id":2,"status_code":400,"message":"Bad request.","origin":{"service":"billing","version":"1.4.2","instance":"billing-7f9c"}}`, recorder.Body.String())
+
+	recent := NewRecent(1)
+	recent.Record(&Error{Code: EINVALID})
+	assert.Equal(t, "billing", recent.Entries()[0].Origin.Service)
+}
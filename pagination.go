@@ -0,0 +1,47 @@
+package ergo
+
+import "fmt"
+
+// InvalidPageSize returns an EINVALID error for a page size outside 1..maxPageSize
+func InvalidPageSize(pageSize int, maxPageSize int) *Error {
+	return construct(&Error{
+		Code:    EINVALID,
+		Message: fmt.Sprintf("Page size must be between 1 and %d.", maxPageSize),
+		Details: map[string]interface{}{
+			"page_size":     pageSize,
+			"max_page_size": maxPageSize,
+		},
+	})
+}
+
+// InvalidPage returns an EINVALID error for a page number outside 1..lastPage
+func InvalidPage(page int, lastPage int) *Error {
+	return construct(&Error{
+		Code:    EINVALID,
+		Message: fmt.Sprintf("Page must be between 1 and %d.", lastPage),
+		Details: map[string]interface{}{
+			"page":        page,
+			"valid_range": validRange(1, int64(lastPage)),
+		},
+	})
+}
+
+// RangeNotSatisfiable returns an ERANGE error for a Range header that does not
+// overlap the size of the resource, expressed in the given unit (e.g. bytes)
+func RangeNotSatisfiable(unit string, size int64) *Error {
+	return construct(&Error{
+		Code: ERANGE,
+		Details: map[string]interface{}{
+			"unit":        unit,
+			"size":        size,
+			"valid_range": validRange(0, size-1),
+		},
+	})
+}
+
+func validRange(min int64, max int64) map[string]interface{} {
+	return map[string]interface{}{
+		"min": min,
+		"max": max,
+	}
+}
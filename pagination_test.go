@@ -0,0 +1,33 @@
+package ergo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidPageSize(t *testing.T) {
+	err := InvalidPageSize(500, 100)
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "Page size must be between 1 and 100.", ErrorMessage(err))
+	assert.Equal(t, 100, ErrorDetails(err)["max_page_size"])
+	assert.Equal(t, 500, ErrorDetails(err)["page_size"])
+}
+
+func TestInvalidPage(t *testing.T) {
+	err := InvalidPage(12, 10)
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "Page must be between 1 and 10.", ErrorMessage(err))
+	expected := map[string]interface{}{"min": int64(1), "max": int64(10)}
+	assert.Equal(t, expected, ErrorDetails(err)["valid_range"])
+}
+
+func TestRangeNotSatisfiable(t *testing.T) {
+	err := RangeNotSatisfiable("bytes", 1024)
+	assert.Equal(t, ERANGE, ErrorCode(err))
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, ErrorStatusCode(err))
+	assert.Equal(t, "Range not satisfiable.", ErrorMessage(err))
+	expected := map[string]interface{}{"min": int64(0), "max": int64(1023)}
+	assert.Equal(t, expected, ErrorDetails(err)["valid_range"])
+}
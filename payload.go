@@ -0,0 +1,35 @@
+package ergo
+
+import (
+	"fmt"
+	"mime"
+)
+
+// PayloadTooLarge returns an EPAYLOADTOOLARGE error for a request body exceeding limit bytes
+func PayloadTooLarge(limit int64) *Error {
+	return construct(&Error{
+		Code:    EPAYLOADTOOLARGE,
+		Message: fmt.Sprintf("Request body must not exceed %d bytes.", limit),
+		Details: map[string]interface{}{
+			"limit": limit,
+		},
+	})
+}
+
+// UnsupportedMediaType returns an EUNSUPPORTEDMEDIA error for the Content-Type of the request,
+// listing the supported media types.
+func UnsupportedMediaType(contentType string, supported ...string) *Error {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+	details := map[string]interface{}{
+		"content_type": contentType,
+	}
+	if len(supported) > 0 {
+		details["supported"] = supported
+	}
+	return construct(&Error{
+		Code:    EUNSUPPORTEDMEDIA,
+		Details: details,
+	})
+}
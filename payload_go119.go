@@ -0,0 +1,21 @@
+//go:build go1.19
+// +build go1.19
+
+package ergo
+
+import (
+	"errors"
+	"net/http"
+)
+
+// FromMaxBytesError returns an EPAYLOADTOOLARGE error carrying the limit of the
+// http.MaxBytesError found in the error chain, or nil.
+func FromMaxBytesError(err error) *Error {
+	var maxBytesError *http.MaxBytesError
+	if !errors.As(err, &maxBytesError) {
+		return nil
+	}
+	e := PayloadTooLarge(maxBytesError.Limit)
+	e.Err = err
+	return e
+}
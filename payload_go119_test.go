@@ -0,0 +1,27 @@
+//go:build go1.19
+// +build go1.19
+
+package ergo
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMaxBytesError(t *testing.T) {
+	// Test with normal error
+	assert.Nil(t, FromMaxBytesError(errors.New("some error")))
+
+	body := http.MaxBytesReader(httptest.NewRecorder(), ioutil.NopCloser(strings.NewReader("too large")), 4)
+	_, readErr := ioutil.ReadAll(body)
+	err := FromMaxBytesError(readErr)
+	assert.Equal(t, EPAYLOADTOOLARGE, ErrorCode(err))
+	assert.Equal(t, int64(4), ErrorDetails(err)["limit"])
+	assert.Equal(t, readErr, err.Err)
+}
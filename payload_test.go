@@ -0,0 +1,28 @@
+package ergo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadTooLarge(t *testing.T) {
+	err := PayloadTooLarge(1024)
+	assert.Equal(t, EPAYLOADTOOLARGE, ErrorCode(err))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, ErrorStatusCode(err))
+	assert.Equal(t, "Request body must not exceed 1024 bytes.", ErrorMessage(err))
+	assert.Equal(t, int64(1024), ErrorDetails(err)["limit"])
+}
+
+func TestUnsupportedMediaType(t *testing.T) {
+	err := UnsupportedMediaType("text/xml; charset=utf-8", "application/json")
+	assert.Equal(t, EUNSUPPORTEDMEDIA, ErrorCode(err))
+	assert.Equal(t, http.StatusUnsupportedMediaType, ErrorStatusCode(err))
+	assert.Equal(t, "Unsupported media type.", ErrorMessage(err))
+	expected := map[string]interface{}{
+		"content_type": "text/xml",
+		"supported":    []string{"application/json"},
+	}
+	assert.Equal(t, expected, ErrorDetails(err))
+}
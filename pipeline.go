@@ -0,0 +1,53 @@
+package ergo
+
+// Stages of the data pipelines
+const (
+	StageExtract   = "extract"
+	StageTransform = "transform"
+	StageLoad      = "load"
+)
+
+// Fields describing the failure of a stage of a data pipeline
+const (
+	FieldPipeline        = "pipeline"
+	FieldStage           = "pipeline_stage"
+	FieldRecordsAffected = "records_affected"
+)
+
+// TagResumable tags the pipeline failures that can be resumed from the last checkpoint
+// instead of rerunning the whole pipeline
+const TagResumable = "resumable"
+
+// StageFailure returns an error for the failure of the stage of the pipeline, wrapping err,
+// with the operation "<pipeline>.<stage>" and the pipeline, the stage and the number of records
+// affected in the fields, so batch systems report in the same vocabulary as the HTTP services.
+// The code of err is kept, if any. Resumable failures are tagged with TagResumable.
+func StageFailure(pipeline string, stage string, records int64, resumable bool, err error) *Error {
+	e := wrap(err, pipeline+"."+stage)
+	e.Fields = map[string]interface{}{
+		FieldPipeline:        pipeline,
+		FieldStage:           stage,
+		FieldRecordsAffected: records,
+	}
+	if resumable {
+		e.Tags = []string{TagResumable}
+	}
+	return construct(e)
+}
+
+// ErrorStage returns the pipeline stage that has failed, if available
+func ErrorStage(err error) string {
+	stage, _ := ErrorFields(err)[FieldStage].(string)
+	return stage
+}
+
+// ErrorRecordsAffected returns the number of records affected by the failure of a pipeline stage
+func ErrorRecordsAffected(err error) int64 {
+	records, _ := ErrorFields(err)[FieldRecordsAffected].(int64)
+	return records
+}
+
+// ErrorResumable reports whether the failure of a pipeline stage can be resumed
+func ErrorResumable(err error) bool {
+	return MatchTag(TagResumable)(err)
+}
@@ -0,0 +1,36 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStageFailure(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := StageFailure("orders", StageLoad, 120, true, cause)
+	assert.NotEmpty(t, ErrorStack(err))
+	err.Stack = nil
+
+	assert.Equal(t, &Error{
+		Op:  "orders.load",
+		Err: cause,
+		Fields: map[string]interface{}{
+			FieldPipeline:        "orders",
+			FieldStage:           StageLoad,
+			FieldRecordsAffected: int64(120),
+		},
+		Tags: []string{TagResumable},
+	}, err)
+	assert.Equal(t, StageLoad, ErrorStage(err))
+	assert.Equal(t, int64(120), ErrorRecordsAffected(err))
+	assert.True(t, ErrorResumable(err))
+
+	// Test with a classified error, wrapped by the service
+	err = StageFailure("orders", StageTransform, 3, false, &Error{Code: EINVALID, Message: "Missing currency."})
+	wrapped := &Error{Op: "batch.Run", Err: err}
+	assert.Equal(t, EINVALID, ErrorCode(wrapped))
+	assert.Equal(t, StageTransform, ErrorStage(wrapped))
+	assert.False(t, ErrorResumable(wrapped))
+}
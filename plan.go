@@ -0,0 +1,25 @@
+package ergo
+
+import "fmt"
+
+// PlanLimitCode is the code of the errors returned by PlanLimit, e.g. EPAYMENTREQUIRED,
+// EFORBIDDEN or ETOOMANYREQUESTS depending on the billing policy.
+var PlanLimitCode = EPAYMENTREQUIRED
+
+// PlanLimit returns an error for a quota of the plan of the account that has been reached,
+// carrying the quota, its limit, the current usage and, if not empty, the URL to upgrade the plan.
+func PlanLimit(quota string, limit int64, usage int64, upgradeURL string) *Error {
+	err := construct(&Error{
+		Code:    PlanLimitCode,
+		Message: fmt.Sprintf("Plan limit of %d %s reached.", limit, quota),
+		Details: map[string]interface{}{
+			"quota": quota,
+			"limit": limit,
+			"usage": usage,
+		},
+	})
+	if upgradeURL != "" {
+		err.setDetail("upgrade_url", upgradeURL)
+	}
+	return err
+}
@@ -0,0 +1,25 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanLimit(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	err := PlanLimit("seats", 5, 5, "https://example.com/billing/upgrade")
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, err)
+	assert.Equal(t, 402, recorder.Code)
+	assert.JSONEq(t, `{"code":"payment_required","error_id":"err-1","code_id":16,"status_code":402,"message":"Plan limit of 5 seats reached.",`+
+		`"details":{"quota":"seats","limit":5,"usage":5,"upgrade_url":"https://example.com/billing/upgrade"}}`, recorder.Body.String())
+
+	// Test with another code and without upgrade URL
+	defer func() { PlanLimitCode = EPAYMENTREQUIRED }()
+	PlanLimitCode = ETOOMANYREQUESTS
+	err = PlanLimit("api_calls", 1000, 1200, "")
+	assert.Equal(t, 429, ErrorStatusCode(err))
+	assert.NotContains(t, ErrorDetails(err), "upgrade_url")
+}
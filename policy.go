@@ -0,0 +1,57 @@
+package ergo
+
+import "fmt"
+
+// Fields describing the policy that denied the request
+const (
+	FieldPolicyID      = "policy_id"
+	FieldPolicyRule    = "policy_rule"
+	FieldPolicyReasons = "policy_reasons"
+)
+
+// PolicyDenied returns an EFORBIDDEN error for a request denied by the rule of the policy,
+// carrying the policy, the rule and the reasons of the denial in the fields.
+func PolicyDenied(policyID string, rule string, reasons ...string) *Error {
+	fields := map[string]interface{}{
+		FieldPolicyID:   policyID,
+		FieldPolicyRule: rule,
+	}
+	if len(reasons) > 0 {
+		fields[FieldPolicyReasons] = reasons
+	}
+	return construct(&Error{
+		Code:   EFORBIDDEN,
+		Fields: fields,
+	})
+}
+
+// FromPolicyDecision returns the error of the decision of the rule of an Open Policy Agent
+// policy, i.e. the result of the Data API and the error of the query, or nil if the request is allowed.
+// The result is either a boolean or an object with an "allow" boolean and the "reasons" of the denial.
+// Failed queries and undefined results deny the request.
+func FromPolicyDecision(policyID string, rule string, result interface{}, err error) error {
+	if err != nil {
+		e := PolicyDenied(policyID, rule)
+		e.Err = err
+		e.Cause = CauseDependencyFailure
+		return e
+	}
+
+	switch decision := result.(type) {
+	case bool:
+		if decision {
+			return nil
+		}
+	case map[string]interface{}:
+		if allow, _ := decision["allow"].(bool); allow {
+			return nil
+		}
+		reasons, _ := decision["reasons"].([]interface{})
+		messages := make([]string, 0, len(reasons))
+		for _, reason := range reasons {
+			messages = append(messages, fmt.Sprint(reason))
+		}
+		return PolicyDenied(policyID, rule, messages...)
+	}
+	return PolicyDenied(policyID, rule)
+}
@@ -0,0 +1,35 @@
+package ergo
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromPolicyDecision(t *testing.T) {
+	// Test with allowed decisions
+	assert.Nil(t, FromPolicyDecision("authz", "allow", true, nil))
+	assert.Nil(t, FromPolicyDecision("authz", "allow", map[string]interface{}{"allow": true}, nil))
+
+	// Test with a denied decision
+	err := FromPolicyDecision("authz", "allow", false, nil)
+	assert.Equal(t, EFORBIDDEN, ErrorCode(err))
+	assert.Equal(t, map[string]interface{}{FieldPolicyID: "authz", FieldPolicyRule: "allow"}, ErrorFields(err))
+
+	// Test with the reasons of the denial
+	var result map[string]interface{}
+	_ = json.Unmarshal([]byte(`{"result":{"allow":false,"reasons":["not owner"]}}`), &result)
+	err = FromPolicyDecision("documents", "write", result["result"], nil)
+	assert.Equal(t, []string{"not owner"}, ErrorFields(err)[FieldPolicyReasons])
+	assert.Empty(t, ErrorDetails(err))
+
+	// Test with an undefined decision
+	assert.Equal(t, EFORBIDDEN, ErrorCode(FromPolicyDecision("authz", "allow", nil, nil)))
+
+	// Test with a failed query
+	err = FromPolicyDecision("authz", "allow", nil, errors.New("connection refused"))
+	assert.Equal(t, EFORBIDDEN, ErrorCode(err))
+	assert.Equal(t, CauseDependencyFailure, ErrorCause(err))
+}
@@ -0,0 +1,66 @@
+package ergo
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CheckPrecondition evaluates the If-Match and If-Unmodified-Since headers of the
+// request against the current ETag and modification time of the resource.
+// It returns an EPRECONDITION error carrying the current state when they don't match.
+// A zero lastModified skips the If-Unmodified-Since evaluation.
+func CheckPrecondition(r *http.Request, etag string, lastModified time.Time) error {
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		if !matchETag(ifMatch, etag) {
+			return preconditionError(EPRECONDITION, etag, lastModified)
+		}
+		return nil
+	}
+
+	if ifUnmodifiedSince := r.Header.Get("If-Unmodified-Since"); ifUnmodifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifUnmodifiedSince)
+		if err == nil && lastModified.Truncate(time.Second).After(since) {
+			return preconditionError(EPRECONDITION, etag, lastModified)
+		}
+	}
+	return nil
+}
+
+// RequirePrecondition works like CheckPrecondition, but returns an EPRECONDITIONREQUIRED
+// error when the request has neither an If-Match nor an If-Unmodified-Since header.
+func RequirePrecondition(r *http.Request, etag string, lastModified time.Time) error {
+	if r.Header.Get("If-Match") == "" && r.Header.Get("If-Unmodified-Since") == "" {
+		return preconditionError(EPRECONDITIONREQUIRED, etag, lastModified)
+	}
+	return CheckPrecondition(r, etag, lastModified)
+}
+
+// matchETag reports whether the If-Match header matches the ETag,
+// using the strong comparison required by RFC 7232
+func matchETag(ifMatch string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || (candidate == etag && !strings.HasPrefix(candidate, "W/")) {
+			return true
+		}
+	}
+	return false
+}
+
+func preconditionError(code string, etag string, lastModified time.Time) *Error {
+	details := make(map[string]interface{})
+	if etag != "" {
+		details["etag"] = etag
+	}
+	if !lastModified.IsZero() {
+		details["last_modified"] = lastModified.UTC().Format(http.TimeFormat)
+	}
+	return construct(&Error{
+		Code:    code,
+		Details: details,
+	})
+}
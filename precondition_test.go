@@ -0,0 +1,56 @@
+package ergo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPrecondition(t *testing.T) {
+	lastModified := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Test without conditional headers
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	assert.NoError(t, CheckPrecondition(r, `"v2"`, lastModified))
+
+	// Test with matching If-Match
+	r.Header.Set("If-Match", `"v1", "v2"`)
+	assert.NoError(t, CheckPrecondition(r, `"v2"`, lastModified))
+	r.Header.Set("If-Match", "*")
+	assert.NoError(t, CheckPrecondition(r, `"v2"`, lastModified))
+
+	// Test with not matching If-Match
+	r.Header.Set("If-Match", `"v1"`)
+	err := CheckPrecondition(r, `"v2"`, lastModified)
+	assert.Equal(t, EPRECONDITION, ErrorCode(err))
+	assert.Equal(t, http.StatusPreconditionFailed, ErrorStatusCode(err))
+	assert.Equal(t, `"v2"`, ErrorDetails(err)["etag"])
+	assert.Equal(t, "Mon, 01 Jun 2020 12:00:00 GMT", ErrorDetails(err)["last_modified"])
+
+	// Test with weak ETag
+	r.Header.Set("If-Match", `W/"v2"`)
+	err = CheckPrecondition(r, `W/"v2"`, time.Time{})
+	assert.Equal(t, EPRECONDITION, ErrorCode(err))
+
+	// Test with If-Unmodified-Since
+	r = httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set("If-Unmodified-Since", "Mon, 01 Jun 2020 12:00:00 GMT")
+	assert.NoError(t, CheckPrecondition(r, `"v2"`, lastModified))
+	err = CheckPrecondition(r, `"v2"`, lastModified.Add(time.Minute))
+	assert.Equal(t, EPRECONDITION, ErrorCode(err))
+}
+
+func TestRequirePrecondition(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	err := RequirePrecondition(r, `"v2"`, time.Time{})
+	assert.Equal(t, EPRECONDITIONREQUIRED, ErrorCode(err))
+	assert.Equal(t, http.StatusPreconditionRequired, ErrorStatusCode(err))
+	assert.Equal(t, "Precondition required.", ErrorMessage(err))
+	assert.Equal(t, map[string]interface{}{"etag": `"v2"`}, ErrorDetails(err))
+
+	r.Header.Set("If-Match", `"v2"`)
+	assert.NoError(t, RequirePrecondition(r, `"v2"`, time.Time{}))
+}
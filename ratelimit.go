@@ -0,0 +1,42 @@
+package ergo
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimited returns an ETOOMANYREQUESTS error carrying the quota of the client:
+// the limit, the remaining requests and the reset time, as unix seconds.
+// The writer sends them as X-RateLimit-* headers.
+func RateLimited(limit int, remaining int, reset time.Time) *Error {
+	return construct(&Error{
+		Code: ETOOMANYREQUESTS,
+		Details: map[string]interface{}{
+			"limit":     limit,
+			"remaining": remaining,
+			"reset":     reset.Unix(),
+		},
+	})
+}
+
+// setRateLimitHeaders sets the X-RateLimit-* and Retry-After headers from the
+// quota of a rate limit error
+func setRateLimitHeaders(header http.Header, err error) {
+	details := ErrorDetails(err)
+	limit, hasLimit := details["limit"].(int)
+	remaining, hasRemaining := details["remaining"].(int)
+	reset, hasReset := details["reset"].(int64)
+	if ErrorCode(err) != ETOOMANYREQUESTS || !hasLimit || !hasRemaining || !hasReset {
+		return
+	}
+
+	header.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+	retryAfter := reset - now().Unix()
+	if retryAfter < 0 {
+		retryAfter = 0
+	}
+	header.Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+}
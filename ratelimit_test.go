@@ -0,0 +1,33 @@
+package ergo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimited(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second)
+	err := RateLimited(100, 0, reset)
+	assert.Equal(t, ETOOMANYREQUESTS, ErrorCode(err))
+	assert.Equal(t, http.StatusTooManyRequests, ErrorStatusCode(err))
+	assert.Equal(t, "Too many requests.", ErrorMessage(err))
+
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, err)
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "100", recorder.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, strconv.FormatInt(reset.Unix(), 10), recorder.Header().Get("X-RateLimit-Reset"))
+	retryAfter, _ := strconv.Atoi(recorder.Header().Get("Retry-After"))
+	assert.InDelta(t, 30, retryAfter, 1)
+
+	// Test without quota
+	recorder = httptest.NewRecorder()
+	WriteError(recorder, &Error{Code: ETOOMANYREQUESTS})
+	assert.Empty(t, recorder.Header().Get("X-RateLimit-Limit"))
+}
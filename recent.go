@@ -0,0 +1,79 @@
+package ergo
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RecentError defines a handled error kept by Recent.
+// Only the client representation is kept, the wrapped error is discarded.
+type RecentError struct {
+	Time  time.Time `json:"time"`
+	Op    string    `json:"op,omitempty"`
+	Cause string    `json:"cause,omitempty"`
+	JSONError
+}
+
+// Recent keeps the last handled errors in a ring buffer.
+// It implements http.Handler to inspect them, like /debug/vars.
+// It is safe for concurrent use.
+type Recent struct {
+	mu      sync.Mutex
+	entries []RecentError
+	next    int
+	full    bool
+}
+
+// NewRecent returns a Recent keeping the last size errors
+func NewRecent(size int) *Recent {
+	if size < 1 {
+		size = 1
+	}
+	return &Recent{entries: make([]RecentError, size)}
+}
+
+// Record adds the error to the buffer, overwriting the oldest one when full
+func (r *Recent) Record(err error) {
+	if err == nil {
+		return
+	}
+	entry := RecentError{
+		Time:      now().UTC(),
+		Op:        errorOp(err),
+		Cause:     ErrorCause(err),
+		JSONError: FormatError(err),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Entries returns the kept errors, the most recent first
+func (r *Recent) Entries() []RecentError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := r.next
+	if r.full {
+		count = len(r.entries)
+	}
+	entries := make([]RecentError, 0, count)
+	for i := 1; i <= count; i++ {
+		index := (r.next - i + len(r.entries)) % len(r.entries)
+		entries = append(entries, r.entries[index])
+	}
+	return entries
+}
+
+// ServeHTTP writes the kept errors as Json
+func (r *Recent) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(r.Entries())
+}
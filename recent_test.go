@@ -0,0 +1,51 @@
+package ergo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentRecord(t *testing.T) {
+	recent := NewRecent(2)
+
+	// Test with nil error
+	recent.Record(nil)
+	assert.Empty(t, recent.Entries())
+
+	recent.Record(&Error{Code: EINVALID, Op: "user.Create"})
+	entries := recent.Entries()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "user.Create", entries[0].Op)
+	assert.Equal(t, EINVALID, entries[0].Code)
+
+	// Test that the oldest error is overwritten
+	recent.Record(&Error{Code: ENOTFOUND})
+	recent.Record(errors.New("secret database error"))
+	entries = recent.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, EINTERNAL, entries[0].Code)
+	assert.Equal(t, "An internal error has occurred.", entries[0].Message)
+	assert.Equal(t, ENOTFOUND, entries[1].Code)
+}
+
+func TestRecentServeHTTP(t *testing.T) {
+	recent := NewRecent(10)
+	recent.Record(&Error{Code: ECONFLICT, Message: "already exists"})
+
+	recorder := httptest.NewRecorder()
+	recent.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/errors", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	var entries []RecentError
+	err := json.Unmarshal(recorder.Body.Bytes(), &entries)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "already exists", entries[0].Message)
+	assert.Equal(t, http.StatusConflict, entries[0].StatusCode)
+}
@@ -0,0 +1,49 @@
+package ergo
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"regexp"
+	"strconv"
+	texttemplate "text/template"
+)
+
+// Fields describing the template that failed to render
+const (
+	FieldTemplate     = "template"
+	FieldTemplateLine = "template_line"
+)
+
+// templateLocation matches the location prefixed to the template errors, e.g. "template: page:12:5: ..."
+var templateLocation = regexp.MustCompile(`^template: ([^:]+):(\d+)`)
+
+// FromTemplateError returns an EINTERNAL error for an html/template or text/template
+// failure found in the error chain, carrying the template name and line in the fields, or nil.
+// The message of the template error is never sent to the client.
+func FromTemplateError(err error) *Error {
+	fields := map[string]interface{}{}
+	var htmlError *htmltemplate.Error
+	var execError texttemplate.ExecError
+	if errors.As(err, &htmlError) {
+		fields[FieldTemplate] = htmlError.Name
+		if htmlError.Line > 0 {
+			fields[FieldTemplateLine] = htmlError.Line
+		}
+	} else if errors.As(err, &execError) {
+		fields[FieldTemplate] = execError.Name
+		if match := templateLocation.FindStringSubmatch(execError.Error()); match != nil {
+			fields[FieldTemplateLine], _ = strconv.Atoi(match[2])
+		}
+	} else if match := templateLocation.FindStringSubmatch(err.Error()); match != nil {
+		// Parse errors are not typed
+		fields[FieldTemplate] = match[1]
+		fields[FieldTemplateLine], _ = strconv.Atoi(match[2])
+	} else {
+		return nil
+	}
+	return construct(&Error{
+		Code:   EINTERNAL,
+		Err:    err,
+		Fields: fields,
+	})
+}
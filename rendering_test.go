@@ -0,0 +1,39 @@
+package ergo
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"io/ioutil"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromTemplateError(t *testing.T) {
+	// Test with normal error
+	assert.Nil(t, FromTemplateError(errors.New("some error")))
+
+	// Test with a missing key
+	page := texttemplate.Must(texttemplate.New("page").Option("missingkey=error").Parse("Hello\n{{.Name}}"))
+	err := FromTemplateError(page.Execute(ioutil.Discard, map[string]string{}))
+	assert.Equal(t, EINTERNAL, ErrorCode(err))
+	assert.Equal(t, "An internal error has occurred.", ErrorMessage(err))
+	assert.Equal(t, map[string]interface{}{FieldTemplate: "page", FieldTemplateLine: 2}, ErrorFields(err))
+
+	// Test with a nil pointer
+	var user *struct{ Name string }
+	html := htmltemplate.Must(htmltemplate.New("profile").Parse("<p>{{.Name}}</p>"))
+	err = FromTemplateError(html.Execute(ioutil.Discard, user))
+	assert.Equal(t, map[string]interface{}{FieldTemplate: "profile", FieldTemplateLine: 1}, ErrorFields(err))
+
+	// Test with an html/template escaping error
+	unsafe := htmltemplate.Must(htmltemplate.New("unsafe").Parse("<a href=\"{{.}}"))
+	err = FromTemplateError(unsafe.Execute(ioutil.Discard, "x"))
+	assert.Equal(t, "unsafe", ErrorFields(err)[FieldTemplate])
+
+	// Test with a parse error
+	_, parseErr := texttemplate.New("broken").Parse("{{.Name")
+	err = FromTemplateError(parseErr)
+	assert.Equal(t, map[string]interface{}{FieldTemplate: "broken", FieldTemplateLine: 1}, ErrorFields(err))
+}
@@ -0,0 +1,122 @@
+package ergo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// RecordedError defines the serialized form of an error stack
+// Text is the message of errors that are not *Error, whose Err is the error they wrap, if any
+type RecordedError struct {
+	Code    string                 `json:"code,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Op      string                 `json:"op,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	Cause   string                 `json:"cause,omitempty"`
+	Hops    []Hop                  `json:"hops,omitempty"`
+	Tags    []string               `json:"tags,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Stack   []Frame                `json:"stack,omitempty"`
+	ErrorID string                 `json:"error_id,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Err     *RecordedError         `json:"err,omitempty"`
+}
+
+// NewRecordedError returns the serialized form of the error stack
+func NewRecordedError(err error) *RecordedError {
+	if err == nil {
+		return nil
+	}
+	e, isCustomError := err.(*Error)
+	if !isCustomError {
+		return &RecordedError{Text: err.Error(), Err: NewRecordedError(nextError(err))}
+	}
+	return &RecordedError{
+		Code:    e.Code,
+		Message: e.Message,
+		Op:      e.Op,
+		Details: e.Details,
+		Cause:   e.Cause,
+		Hops:    e.Hops,
+		Tags:    e.Tags,
+		Fields:  e.Fields,
+		Stack:   e.Stack,
+		ErrorID: e.ErrorID,
+		Err:     NewRecordedError(e.Err),
+	}
+}
+
+// Restore reconstructs the error stack.
+// Errors that were not *Error are restored with errors.New, or as errors with the same
+// text unwrapping to the restored wrapped error,
+// numbers in the details loaded from Json are restored as float64.
+func (r *RecordedError) Restore() error {
+	if r == nil {
+		return nil
+	}
+	if r.Text != "" {
+		if r.Err != nil {
+			return &restoredError{text: r.Text, err: r.Err.Restore()}
+		}
+		return errors.New(r.Text)
+	}
+	return &Error{
+		Code:    r.Code,
+		Message: r.Message,
+		Op:      r.Op,
+		Details: r.Details,
+		Cause:   r.Cause,
+		Hops:    r.Hops,
+		Tags:    r.Tags,
+		Fields:  r.Fields,
+		Stack:   r.Stack,
+		ErrorID: r.ErrorID,
+		Err:     r.Err.Restore(),
+	}
+}
+
+// restoredError is a restored wrapper of an error, e.g. of fmt.Errorf with %w
+type restoredError struct {
+	text string
+	err  error
+}
+
+func (err *restoredError) Error() string {
+	return err.text
+}
+
+func (err *restoredError) Unwrap() error {
+	return err.err
+}
+
+// Recorder saves error stacks as Json files in Dir, to reproduce them with LoadError
+type Recorder struct {
+	Dir string
+}
+
+// Record saves the error stack and returns the path of the file
+func (r *Recorder) Record(err error) (string, error) {
+	data, marshalErr := json.MarshalIndent(NewRecordedError(err), "", "  ")
+	if marshalErr != nil {
+		return "", marshalErr
+	}
+	name := fmt.Sprintf("%s-%s.json", now().UTC().Format("20060102T150405.000000000"), fingerprint(ErrorCode(err), errorOp(err)))
+	path := filepath.Join(r.Dir, name)
+	return path, ioutil.WriteFile(path, data, 0644)
+}
+
+// LoadError reconstructs the error stack saved by Recorder in the file
+func LoadError(path string) (error, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var recorded *RecordedError
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, err
+	}
+	return recorded.Restore(), nil
+}
@@ -0,0 +1,68 @@
+package ergo
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordedError(t *testing.T) {
+	// Test with nil error
+	assert.Nil(t, NewRecordedError(nil))
+	assert.Nil(t, NewRecordedError(nil).Restore())
+
+	error := &Error{
+		Code:    EINVALID,
+		Op:      "user.Create",
+		Details: map[string]interface{}{"field": "email"},
+		Err: &Error{
+			Op:  "db.Insert",
+			Err: errors.New("duplicate key"),
+		},
+	}
+	actual := NewRecordedError(error).Restore()
+	assert.Equal(t, error, actual)
+
+	// Test with the stack and the error_id
+	identified := &Error{
+		Code:    EINTERNAL,
+		Op:      "user.Get",
+		Stack:   []Frame{{Function: "main.main", File: "/src/main.go", Line: 12}},
+		ErrorID: "err-1",
+	}
+	assert.Equal(t, identified, NewRecordedError(identified).Restore())
+
+	// Test with a wrapper of another package
+	wrapped := fmt.Errorf("loading user: %w", &Error{Code: ENOTFOUND, Op: "db.Get"})
+	actual = NewRecordedError(wrapped).Restore()
+	assert.Equal(t, "loading user: db.Get: <not_found>", actual.Error())
+	assert.Equal(t, ENOTFOUND, ErrorCode(actual))
+	assert.Equal(t, &Error{Code: ENOTFOUND, Op: "db.Get"}, errors.Unwrap(actual))
+}
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ergo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	error := &Error{
+		Code: ENOTFOUND,
+		Op:   "user.Get",
+		Err:  errors.New("no rows"),
+	}
+	recorder := &Recorder{Dir: dir}
+	path, err := recorder.Record(error)
+	assert.NoError(t, err)
+
+	actual, err := LoadError(path)
+	assert.NoError(t, err)
+	assert.Equal(t, error, actual)
+
+	// Test with missing file
+	_, err = LoadError(path + ".missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package ergo
+
+import "time"
+
+// Reporter receives the errors handled by HandleError and the writer
+type Reporter interface {
+	Report(err error)
+}
+
+// Reporters receive, in order, every handled error
+var Reporters []Reporter
+
+// Event defines a handled error as recorded by the reporters
+// ID identifies the event, the error_id of the handled error, or generated by the
+// DefaultIDGenerator for the errors that have not been identified
+// Error is the string representation of the error stack
+// Stack is the innermost call stack of the error chain, if captured
+// JSONError is the representation sent to the client
+type Event struct {
+	ID     string                 `json:"id"`
+	Time   time.Time              `json:"time"`
+	Op     string                 `json:"op,omitempty"`
+	Cause  string                 `json:"cause,omitempty"`
+	Error  string                 `json:"error"`
+	Tags   []string               `json:"tags,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	Stack  []Frame                `json:"stack,omitempty"`
+	JSONError
+}
+
+// NewEvent returns the event of the handled error
+func NewEvent(err error) Event {
+	return Event{
+		ID:        eventID(err),
+		Time:      now().UTC(),
+		Op:        errorOp(err),
+		Cause:     ErrorCause(err),
+		Error:     err.Error(),
+		Tags:      ErrorTags(err),
+		Fields:    ErrorFields(err),
+		Stack:     ErrorStack(err),
+		JSONError: FormatError(err),
+	}
+}
+
+// eventID returns the error_id of the error, generating one if it has none
+func eventID(err error) string {
+	if id := ErrorID(err); id != "" {
+		return id
+	}
+	return DefaultIDGenerator.NewID()
+}
+
+// report sends the handled error to the Reporters, or to the SuppressedReporters
+// if it is suppressed, isolating their panics and timeouts
+func report(err error) {
+	if err == nil {
+		return
+	}
+	reporters := Reporters
+	if Suppressed(err) {
+		reporters = SuppressedReporters
+	}
+	safeReport(reporters, err)
+}
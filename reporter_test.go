@@ -0,0 +1,70 @@
+package ergo
+
+import (
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// captureReporter keeps the reported errors
+type captureReporter struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (c *captureReporter) Report(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+func (c *captureReporter) reported() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]error{}, c.errs...)
+}
+
+func TestReporters(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	defer func() { Reporters = nil }()
+	capture := &captureReporter{}
+	Reporters = []Reporter{capture}
+
+	err := &Error{Code: EINVALID}
+	HandleError(err)
+	WriteError(httptest.NewRecorder(), err)
+	HandleError(nil)
+	identified := &Error{Err: err, ErrorID: "err-1"}
+	assert.Equal(t, []error{identified, identified}, capture.reported())
+}
+
+func TestNewEvent(t *testing.T) {
+	error := &Error{
+		Code:   EINTERNAL,
+		Op:     "user.Get",
+		Tags:   []string{"users"},
+		Fields: map[string]interface{}{"table": "users"},
+		Err:    errors.New("connection refused"),
+	}
+	event := NewEvent(error)
+	assert.Equal(t, "user.Get", event.Op)
+	assert.Equal(t, CauseUnknown, event.Cause)
+	assert.Equal(t, "user.Get: connection refused", event.Error)
+	assert.Equal(t, []string{"users"}, event.Tags)
+	assert.Equal(t, "users", event.Fields["table"])
+	assert.Equal(t, FormatError(error), event.JSONError)
+	assert.Empty(t, event.Stack)
+
+	// Test with a captured stack
+	traced := Trace(error)
+	assert.Equal(t, ErrorStack(traced), NewEvent(traced).Stack)
+	assert.NotEmpty(t, NewEvent(traced).Stack)
+
+	// Test with an identified error, whose events share the error_id of the envelope
+	identified := Identify(error)
+	assert.Equal(t, ErrorID(identified), NewEvent(identified).ID)
+	assert.Equal(t, NewEvent(identified).ID, NewEvent(identified).ErrorID)
+}
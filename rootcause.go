@@ -0,0 +1,28 @@
+package ergo
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// TrustedHeader is the request header carrying TrustedSecret
+var TrustedHeader = "Ergo-Debug"
+
+// TrustedSecret is the secret that trusted consumers, e.g. an internal API gateway,
+// send in TrustedHeader to receive the root cause of the errors from ServeError.
+// Root causes are never sent when it is empty.
+var TrustedSecret string
+
+// RootCause returns the innermost error of the stack
+func RootCause(err error) error {
+	if e, isCustomError := err.(*Error); isCustomError && e.Err != nil {
+		return RootCause(e.Err)
+	}
+	return err
+}
+
+// trusted reports whether the request carries the TrustedSecret
+func trusted(r *http.Request) bool {
+	secret := r.Header.Get(TrustedHeader)
+	return TrustedSecret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(TrustedSecret)) == 1
+}
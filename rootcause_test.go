@@ -0,0 +1,47 @@
+package ergo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootCause(t *testing.T) {
+	assert.Nil(t, RootCause(nil))
+
+	cause := errors.New("connection refused")
+	assert.Equal(t, cause, RootCause(cause))
+	assert.Equal(t, cause, RootCause(&Error{Op: "user.Get", Err: &Error{Op: "db.Query", Err: cause}}))
+
+	inner := &Error{Code: ENOTFOUND}
+	assert.Equal(t, inner, RootCause(&Error{Op: "user.Get", Err: inner}))
+}
+
+func TestServeErrorRootCause(t *testing.T) {
+	error := &Error{Code: EINTERNAL, Op: "user.Get", Err: errors.New("connection refused")}
+
+	// Test without TrustedSecret
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(TrustedHeader, "")
+	recorder := httptest.NewRecorder()
+	ServeError(recorder, r, error)
+	assert.NotContains(t, recorder.Body.String(), "connection refused")
+
+	defer func() { TrustedSecret = "" }()
+	TrustedSecret = "s3cret"
+
+	// Test with the wrong secret
+	r.Header.Set(TrustedHeader, "guess")
+	recorder = httptest.NewRecorder()
+	ServeError(recorder, r, error)
+	assert.NotContains(t, recorder.Body.String(), "connection refused")
+
+	// Test with the secret
+	r.Header.Set(TrustedHeader, "s3cret")
+	recorder = httptest.NewRecorder()
+	ServeError(recorder, r, error)
+	assert.Contains(t, recorder.Body.String(), `"root_cause":"connection refused"`)
+}
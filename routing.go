@@ -0,0 +1,99 @@
+package ergo
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// RouteSuggestions is the number of the nearest routes suggested by RouteNotFound,
+// 0 disables the suggestions
+var RouteSuggestions = 3
+
+// MethodNotAllowed returns an EMETHODNOTALLOWED error for the method, carrying the
+// allowed methods under the "allowed" key of the details. The writer sends them as the Allow header.
+func MethodNotAllowed(method string, allowed ...string) *Error {
+	return construct(&Error{
+		Code:    EMETHODNOTALLOWED,
+		Message: fmt.Sprintf("Method %s is not allowed.", method),
+		Details: map[string]interface{}{
+			"method":  method,
+			"allowed": allowed,
+		},
+	})
+}
+
+// RouteNotFound returns an ENOTFOUND error for a path matching none of the routes, carrying
+// the RouteSuggestions nearest routes under the "alternatives" key of the details.
+func RouteNotFound(path string, routes []string) *Error {
+	err := construct(&Error{
+		Code: ENOTFOUND,
+		Details: map[string]interface{}{
+			"path": path,
+		},
+	})
+	if alternatives := nearestRoutes(path, routes, RouteSuggestions); len(alternatives) > 0 {
+		err.setDetail("alternatives", alternatives)
+	}
+	return err
+}
+
+// setAllowHeader sets the Allow header from the allowed methods of a method error
+func setAllowHeader(header http.Header, err error) {
+	allowed, ok := ErrorDetails(err)["allowed"].([]string)
+	if ErrorCode(err) != EMETHODNOTALLOWED || !ok {
+		return
+	}
+	header.Set("Allow", strings.Join(allowed, ", "))
+}
+
+// nearestRoutes returns the n routes nearest to the path by edit distance
+func nearestRoutes(path string, routes []string, n int) []string {
+	if n <= 0 || len(routes) == 0 {
+		return nil
+	}
+	nearest := append([]string{}, routes...)
+	distances := make(map[string]int, len(nearest))
+	for _, route := range nearest {
+		distances[route] = editDistance(path, route)
+	}
+	sort.SliceStable(nearest, func(i, j int) bool {
+		return distances[nearest[i]] < distances[nearest[j]]
+	})
+	if len(nearest) > n {
+		nearest = nearest[:n]
+	}
+	return nearest
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func min3(a int, b int, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,40 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethodNotAllowed(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, MethodNotAllowed("DELETE", "GET", "PUT"))
+	assert.Equal(t, 405, recorder.Code)
+	assert.Equal(t, "GET, PUT", recorder.Header().Get("Allow"))
+	assert.JSONEq(t, `{"code":"method_not_allowed","error_id":"err-1","code_id":18,"status_code":405,"message":"Method DELETE is not allowed.",`+
+		`"details":{"method":"DELETE","allowed":["GET","PUT"]}}`, recorder.Body.String())
+}
+
+func TestRouteNotFound(t *testing.T) {
+	routes := []string{"/users", "/users/{id}", "/orders", "/invoices", "/health"}
+	err := RouteNotFound("/user", routes)
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	assert.Equal(t, map[string]interface{}{
+		"path":         "/user",
+		"alternatives": []string{"/users", "/orders", "/users/{id}"},
+	}, ErrorDetails(err))
+
+	// Test with the suggestions disabled
+	defer func() { RouteSuggestions = 3 }()
+	RouteSuggestions = 0
+	assert.Equal(t, map[string]interface{}{"path": "/user"}, ErrorDetails(RouteNotFound("/user", routes)))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("/users", "/users"))
+	assert.Equal(t, 1, editDistance("/user", "/users"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 6, editDistance("", "/users"))
+}
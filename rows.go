@@ -0,0 +1,76 @@
+package ergo
+
+import "fmt"
+
+// RowError describes the error of a row of a bulk import
+type RowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RowErrors collects the errors of the rows of a bulk import, keeping the first Max of them
+// in detail and counting all of them by code. The zero value keeps no row in detail.
+// It is not safe for concurrent use.
+type RowErrors struct {
+	Max    int
+	rows   []RowError
+	total  int
+	counts map[string]int
+}
+
+// NewRowErrors returns a collector keeping the first max row errors in detail
+func NewRowErrors(max int) *RowErrors {
+	return &RowErrors{Max: max}
+}
+
+// Add records the error of the row, and of its column if not empty. Nil errors are ignored.
+func (r *RowErrors) Add(row int, column string, err error) {
+	if err == nil {
+		return
+	}
+	code := ErrorCode(err)
+	r.total++
+	if r.counts == nil {
+		r.counts = map[string]int{}
+	}
+	r.counts[code]++
+	if len(r.rows) < r.Max {
+		r.rows = append(r.rows, RowError{
+			Row:     row,
+			Column:  column,
+			Code:    code,
+			Message: ErrorMessage(err),
+		})
+	}
+}
+
+// Len returns the number of row errors recorded
+func (r *RowErrors) Len() int {
+	return r.total
+}
+
+// Err returns an EINVALID error summarizing the row errors, or nil if there are none.
+// Its details carry the detailed rows, the total and the counts per code.
+func (r *RowErrors) Err() error {
+	if r.total == 0 {
+		return nil
+	}
+	rows := make([]RowError, len(r.rows))
+	copy(rows, r.rows)
+	counts := make(map[string]int, len(r.counts))
+	for code, count := range r.counts {
+		counts[code] = count
+	}
+	return construct(&Error{
+		Code:    EINVALID,
+		Message: fmt.Sprintf("%d rows could not be imported.", r.total),
+		Details: map[string]interface{}{
+			"rows":      rows,
+			"total":     r.total,
+			"counts":    counts,
+			"truncated": r.total > len(rows),
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package ergo
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowErrors(t *testing.T) {
+	rows := NewRowErrors(2)
+
+	// Test without errors
+	rows.Add(1, "", nil)
+	assert.Nil(t, rows.Err())
+
+	rows.Add(2, "email", FieldRequired("email"))
+	rows.Add(3, "", errors.New("some error"))
+	rows.Add(5, "age", FieldOutOfRange("age", 0, 150))
+	assert.Equal(t, 3, rows.Len())
+
+	err := rows.Err()
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "3 rows could not be imported.", ErrorMessage(err))
+	body, _ := json.Marshal(ErrorDetails(err))
+	assert.JSONEq(t, `{
+		"rows": [
+			{"row": 2, "column": "email", "code": "invalid", "message": "email is required."},
+			{"row": 3, "code": "internal", "message": "An internal error has occurred."}
+		],
+		"total": 3,
+		"counts": {"invalid": 2, "internal": 1},
+		"truncated": true
+	}`, string(body))
+
+	// Test with a literal collector
+	literal := &RowErrors{Max: 10}
+	literal.Add(1, "email", FieldRequired("email"))
+	assert.Equal(t, 1, literal.Len())
+	assert.Equal(t, map[string]int{EINVALID: 1}, ErrorDetails(literal.Err())["counts"])
+
+	// Test with the zero value
+	var zero RowErrors
+	zero.Add(1, "", errors.New("some error"))
+	assert.Equal(t, true, ErrorDetails(zero.Err())["truncated"])
+}
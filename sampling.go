@@ -0,0 +1,51 @@
+package ergo
+
+import (
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// StackRates maps the application error codes to the rate, between 0 and 1, of the
+// errors constructed by the package whose stack is captured, e.g. 0.01 for EINVALID.
+var StackRates = map[string]float64{
+	EINTERNAL: 1,
+}
+
+// DefaultStackRate is the rate of the errors whose code is not present in StackRates
+// whose stack is captured.
+var DefaultStackRate float64
+
+// stackRandom returns the random numbers sampling the stacks
+var stackRandom = rand.Float64
+
+// packagePrefix is the prefix of the functions of the package, skipped in the stacks
+var packagePrefix = reflect.TypeOf(Error{}).PkgPath() + "."
+
+// CaptureStack captures the stack of the caller into the error, as sampled by the StackRates
+// for the code the error is classified with, e.g. EINTERNAL for a wrapped unclassified error.
+// The stacks of the errors constructed by the package are captured automatically.
+func (err *Error) CaptureStack() *Error {
+	err = err.own()
+	p := policy()
+	rate, ok := p.StackRates[errorCode(p, err)]
+	if !ok {
+		rate = DefaultStackRate
+	}
+	if rate > 0 && (rate >= 1 || stackRandom() < rate) {
+		err.Stack = callers()
+	}
+	return err
+}
+
+// callers returns the call stack, without the frames of the package
+func callers() []Frame {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	stack := callersFrames(pcs[:n])
+	for len(stack) > 0 && strings.HasPrefix(stack[0].Function, packagePrefix) && !strings.HasSuffix(stack[0].File, "_test.go") {
+		stack = stack[1:]
+	}
+	return stack
+}
@@ -0,0 +1,39 @@
+package ergo
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureStack(t *testing.T) {
+	defer func() {
+		StackRates = map[string]float64{EINTERNAL: 1}
+		stackRandom = rand.Float64
+	}()
+	StackRates[EINVALID] = 0.01
+	random := 0.5
+	stackRandom = func() float64 { return random }
+
+	// Test with an internal error
+	err := construct(&Error{Code: EINTERNAL})
+	assert.NotEmpty(t, err.Stack)
+	assert.Equal(t, "github.com/skullflow/ergo.TestCaptureStack", err.Stack[0].Function)
+
+	// Test with a sampled code
+	assert.Empty(t, FieldRequired("email").Stack)
+	random = 0.001
+	err = FieldRequired("email")
+	assert.Equal(t, "github.com/skullflow/ergo.TestCaptureStack", err.Stack[0].Function)
+	assert.Equal(t, err.Stack, ErrorStack(err))
+
+	// Test with a code without rate
+	assert.Empty(t, (&Error{Code: ENOTFOUND}).CaptureStack().Stack)
+
+	// Test with the wrapped errors, sampled with the code they are classified with
+	assert.NotEmpty(t, ErrorStack(Trace(errors.New("connection refused"))))
+	assert.NotEmpty(t, ErrorStack(Wrap(errors.New("connection refused"), "user.Get")))
+	assert.Empty(t, ErrorStack(Wrap(&Error{Code: ENOTFOUND}, "user.Get")))
+}
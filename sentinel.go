@@ -0,0 +1,50 @@
+package ergo
+
+import "sync"
+
+var (
+	sentinelMu sync.RWMutex
+	sentinels  = map[string]*Error{}
+)
+
+// Sentinel returns the shared error of the code, allocated once, for the paths creating
+// errors without per-instance data at high volume, e.g. an auth middleware rejecting requests.
+// The mutators of the shared error, e.g. SetRequestID, return a decorated copy and leave it unchanged.
+func Sentinel(code string) *Error {
+	sentinelMu.RLock()
+	err, ok := sentinels[code]
+	sentinelMu.RUnlock()
+	if ok {
+		return err
+	}
+
+	sentinelMu.Lock()
+	defer sentinelMu.Unlock()
+	if err, ok := sentinels[code]; ok {
+		return err
+	}
+	err = &Error{Code: code, shared: true}
+	sentinels[code] = err
+	return err
+}
+
+// Clone returns a copy of the error whose details, fields, hops, tags and stack can be
+// modified without affecting the original error. The wrapped error is shared.
+func (err *Error) Clone() *Error {
+	clone := *err
+	clone.Details = mergeMaps(nil, err.Details)
+	clone.Fields = mergeMaps(nil, err.Fields)
+	clone.Hops = append([]Hop(nil), err.Hops...)
+	clone.Tags = append([]string(nil), err.Tags...)
+	clone.Stack = append([]Frame(nil), err.Stack...)
+	clone.shared = false
+	return &clone
+}
+
+// own returns the error, or a copy of it if it is a sentinel, for the mutators to modify
+func (err *Error) own() *Error {
+	if err.shared {
+		return err.Clone()
+	}
+	return err
+}
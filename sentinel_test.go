@@ -0,0 +1,84 @@
+package ergo
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentinel(t *testing.T) {
+	err := Sentinel(EUNAUTHORIZED)
+	assert.Same(t, err, Sentinel(EUNAUTHORIZED))
+	assert.Equal(t, EUNAUTHORIZED, ErrorCode(err))
+	assert.Equal(t, "Unauthorized.", ErrorMessage(err))
+
+	// Test that the sentinel is not allocated again
+	allocations := testing.AllocsPerRun(100, func() {
+		_ = Sentinel(EUNAUTHORIZED)
+	})
+	assert.Equal(t, float64(0), allocations)
+}
+
+func TestClone(t *testing.T) {
+	original := &Error{Code: EINVALID, Details: map[string]interface{}{"field": "email"}, Tags: []string{"users"}}
+	clone := original.Clone()
+	clone.Message = "email is invalid"
+	clone.setDetail("field", "name")
+	clone.Tags[0] = "accounts"
+
+	assert.Equal(t, &Error{Code: EINVALID, Details: map[string]interface{}{"field": "email"}, Tags: []string{"users"}}, original)
+	assert.Equal(t, "name", ErrorDetails(clone)["field"])
+	assert.Equal(t, "email is invalid", ErrorMessage(clone))
+
+	// Test with a sentinel
+	decorated := Sentinel(EFORBIDDEN).Clone().SetRequestID("req-1")
+	assert.Equal(t, "req-1", ErrorRequestID(decorated))
+	assert.Empty(t, Sentinel(EFORBIDDEN).Details)
+}
+
+func TestSentinelMutators(t *testing.T) {
+	sentinel := Sentinel(EFORBIDDEN)
+
+	// Test that decorating the sentinel leaves it unchanged
+	decorated := sentinel.SetRequestID("req-1").SetResource("user", "42")
+	assert.NotSame(t, sentinel, decorated)
+	assert.Equal(t, "req-1", ErrorRequestID(decorated))
+	assert.Equal(t, "42", ErrorResourceID(decorated))
+	decorated.SetUserID("user-1")
+	assert.Equal(t, "user-1", ErrorUserID(decorated))
+
+	ctx := WithTemplate(context.Background(), Template{OpPrefix: "billing", Tags: []string{"payments"}, DocsURL: "https://docs.example.com/errors"})
+	templated := ApplyTemplate(ctx, sentinel)
+	assert.Equal(t, "billing", templated.Op)
+	assert.Equal(t, []string{"payments"}, templated.Tags)
+
+	deprecated := sentinel.SetDeprecation(Deprecation{Link: "https://docs.example.com/v1"})
+	_, ok := ErrorDeprecation(deprecated)
+	assert.True(t, ok)
+
+	assert.Same(t, sentinel, Sentinel(EFORBIDDEN))
+	assert.Equal(t, &Error{Code: EFORBIDDEN, shared: true}, Sentinel(EFORBIDDEN))
+}
+
+func BenchmarkSentinel(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Sentinel(EUNAUTHORIZED)
+	}
+}
+
+func BenchmarkNewError(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = construct(&Error{Code: EUNAUTHORIZED})
+	}
+}
+
+func BenchmarkWriteSentinel(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		WriteError(httptest.NewRecorder(), Sentinel(EUNAUTHORIZED))
+	}
+}
@@ -0,0 +1,92 @@
+package ergo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+)
+
+// Divergence describes a request whose error envelopes differ between the primary and
+// the shadow handler. Fields lists the fields of the envelope that differ.
+type Divergence struct {
+	Method  string
+	Path    string
+	Primary JSONError
+	Shadow  JSONError
+	Fields  []string
+}
+
+// Shadow returns a handler serving the requests with primary, then running them against
+// shadow and sending to sink the divergences of their error envelopes (code, status,
+// message and details). The response of shadow is discarded. The shadow runs in the
+// request goroutine, after the response of primary has been written.
+func Shadow(primary http.Handler, shadow http.Handler, sink func(Divergence)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		if r.Body != nil {
+			body, _ = ioutil.ReadAll(r.Body)
+		}
+
+		primaryRecorder := httptest.NewRecorder()
+		primary.ServeHTTP(primaryRecorder, shadowRequest(r, body))
+		for key, values := range primaryRecorder.Header() {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(primaryRecorder.Code)
+		_, _ = w.Write(primaryRecorder.Body.Bytes())
+
+		shadowRecorder := httptest.NewRecorder()
+		shadow.ServeHTTP(shadowRecorder, shadowRequest(r, body))
+
+		primaryError := recordedEnvelope(primaryRecorder)
+		shadowError := recordedEnvelope(shadowRecorder)
+		if fields := divergentFields(primaryError, shadowError); len(fields) > 0 {
+			sink(Divergence{
+				Method:  r.Method,
+				Path:    r.URL.Path,
+				Primary: primaryError,
+				Shadow:  shadowError,
+				Fields:  fields,
+			})
+		}
+	})
+}
+
+// shadowRequest returns a copy of the request reading the body
+func shadowRequest(r *http.Request, body []byte) *http.Request {
+	clone := r.WithContext(r.Context())
+	clone.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return clone
+}
+
+// recordedEnvelope returns the error envelope of the response, with the status of the
+// response and an empty code if the response is not an error
+func recordedEnvelope(recorder *httptest.ResponseRecorder) JSONError {
+	var jsonError JSONError
+	if recorder.Code >= http.StatusBadRequest {
+		_ = json.Unmarshal(recorder.Body.Bytes(), &jsonError)
+	}
+	jsonError.StatusCode = recorder.Code
+	return jsonError
+}
+
+// divergentFields returns the fields of the envelopes that differ
+func divergentFields(primary JSONError, shadow JSONError) []string {
+	var fields []string
+	if primary.Code != shadow.Code {
+		fields = append(fields, "code")
+	}
+	if primary.StatusCode != shadow.StatusCode {
+		fields = append(fields, "status_code")
+	}
+	if primary.Message != shadow.Message {
+		fields = append(fields, "message")
+	}
+	if !reflect.DeepEqual(primary.Details, shadow.Details) {
+		fields = append(fields, "details")
+	}
+	return fields
+}
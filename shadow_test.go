@@ -0,0 +1,51 @@
+package ergo
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShadow(t *testing.T) {
+	legacy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"invalid","status_code":400,"message":"Body is required."}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	migrated := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) == "" {
+			WriteError(w, &Error{Code: EINVALID, Message: "Body must not be empty."})
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	var divergences []Divergence
+	handler := Shadow(legacy, migrated, func(divergence Divergence) {
+		divergences = append(divergences, divergence)
+	})
+
+	// Test with the same behavior
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/users", strings.NewReader(`{"name":"jdoe"}`)))
+	assert.Equal(t, 201, recorder.Code)
+	assert.Empty(t, divergences)
+
+	// Test with a divergent message
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/users", nil))
+	assert.Equal(t, 400, recorder.Code)
+	assert.JSONEq(t, `{"code":"invalid","status_code":400,"message":"Body is required."}`, recorder.Body.String())
+	assert.Len(t, divergences, 1)
+	assert.Equal(t, "/users", divergences[0].Path)
+	assert.Equal(t, []string{"message"}, divergences[0].Fields)
+	assert.Equal(t, "Body must not be empty.", divergences[0].Shadow.Message)
+}
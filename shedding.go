@@ -0,0 +1,70 @@
+package ergo
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryDistribution maps a random number in [0, 1) to the Retry-After of a shed request
+type RetryDistribution func(random float64) time.Duration
+
+// UniformRetry draws the Retry-After uniformly between min and max
+func UniformRetry(min time.Duration, max time.Duration) RetryDistribution {
+	return func(random float64) time.Duration {
+		return min + time.Duration(random*float64(max-min))
+	}
+}
+
+// ExponentialRetry draws the Retry-After from an exponential distribution of the mean,
+// shifted by min and capped to max: most clients retry early, a long tail later.
+func ExponentialRetry(min time.Duration, mean time.Duration, max time.Duration) RetryDistribution {
+	return func(random float64) time.Duration {
+		retryAfter := min + time.Duration(-math.Log(1-random)*float64(mean))
+		if retryAfter > max {
+			return max
+		}
+		return retryAfter
+	}
+}
+
+// Shedder is a middleware converting the load shedding decisions into EUNAVAILABLE errors
+// whose Retry-After is drawn from the Distribution, so that the shed clients do not retry
+// all at once after an outage. Random draws the distribution input, rand.Float64 if nil.
+type Shedder struct {
+	Message      string
+	Distribution RetryDistribution
+	Random       func() float64
+}
+
+// NewShedder returns a Shedder drawing the Retry-After of the errors from the distribution
+func NewShedder(message string, distribution RetryDistribution) *Shedder {
+	return &Shedder{Message: message, Distribution: distribution, Random: rand.Float64}
+}
+
+// Shed returns the EUNAVAILABLE error of a shed request, with a jittered Retry-After
+// rounded up to the second
+func (s *Shedder) Shed() *Error {
+	random := rand.Float64
+	if s.Random != nil {
+		random = s.Random
+	}
+	retryAfter := s.Distribution(random())
+	if rest := retryAfter % time.Second; rest != 0 {
+		retryAfter += time.Second - rest
+	}
+	return Unavailable(s.Message, retryAfter)
+}
+
+// Handler returns the middleware serving the error of Shed in place of next
+// for the requests that shed decides to drop
+func (s *Shedder) Handler(shed func(r *http.Request) bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if shed(r) {
+			ServeError(w, r, s.Shed())
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,57 @@
+package ergo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDistributions(t *testing.T) {
+	// Test with a uniform distribution
+	uniform := UniformRetry(10*time.Second, 30*time.Second)
+	assert.Equal(t, 10*time.Second, uniform(0))
+	assert.Equal(t, 20*time.Second, uniform(0.5))
+
+	// Test with an exponential distribution, capped to max
+	exponential := ExponentialRetry(5*time.Second, 10*time.Second, time.Minute)
+	assert.Equal(t, 5*time.Second, exponential(0))
+	assert.Equal(t, time.Minute, exponential(0.999))
+}
+
+func TestShedder(t *testing.T) {
+	shedder := NewShedder("Service overloaded.", UniformRetry(10*time.Second, 30*time.Second))
+	randoms := []float64{0.26, 0.9}
+	shedder.Random = func() float64 {
+		random := randoms[0]
+		randoms = randoms[1:]
+		return random
+	}
+
+	// Test with the jittered Retry-After, rounded up to the second
+	err := shedder.Shed()
+	assert.Equal(t, EUNAVAILABLE, err.Code)
+	assert.Equal(t, int64(16), err.Details["retry_after"])
+
+	// Test with the middleware
+	handler := shedder.Handler(func(r *http.Request) bool {
+		return r.URL.Path != "/health"
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "28", w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	// Test with a literal shedder, without Random
+	literal := &Shedder{Distribution: UniformRetry(10*time.Second, 30*time.Second)}
+	retryAfter := literal.Shed().Details["retry_after"].(int64)
+	assert.True(t, retryAfter >= 10 && retryAfter <= 30)
+}
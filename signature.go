@@ -0,0 +1,30 @@
+package ergo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader is the header carrying the signature of the error body
+const SignatureHeader = "Ergo-Signature"
+
+// SigningKey is the shared key used to sign the error bodies written by WriteError.
+// Bodies are not signed when it is empty.
+var SigningKey []byte
+
+// SignBody returns the HMAC-SHA256 signature of the body, formatted as sha256=<hex>
+func SignBody(key []byte, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether the signature of the body was produced with the key
+func VerifySignature(key []byte, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(SignBody(key, body)), []byte(signature))
+}
@@ -0,0 +1,34 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	key := []byte("secret")
+	body := []byte(`{"code":"invalid"}`)
+	signature := SignBody(key, body)
+
+	assert.True(t, VerifySignature(key, body, signature))
+	assert.False(t, VerifySignature([]byte("other"), body, signature))
+	assert.False(t, VerifySignature(key, []byte(`{"code":"internal"}`), signature))
+	assert.False(t, VerifySignature(key, body, signature[len("sha256="):]))
+}
+
+func TestWriteErrorSignature(t *testing.T) {
+	// Test without SigningKey
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, &Error{Code: EINVALID})
+	assert.Empty(t, recorder.Header().Get(SignatureHeader))
+
+	// Test with SigningKey
+	defer func() { SigningKey = nil }()
+	SigningKey = []byte("secret")
+	recorder = httptest.NewRecorder()
+	WriteError(recorder, &Error{Code: EINVALID})
+	signature := recorder.Header().Get(SignatureHeader)
+	assert.True(t, VerifySignature(SigningKey, recorder.Body.Bytes(), signature))
+}
@@ -0,0 +1,128 @@
+package ergo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+// SOAP versions of the faults
+const (
+	SOAP11 = "1.1"
+	SOAP12 = "1.2"
+)
+
+// Namespaces of the SOAP envelopes
+const (
+	soap11Namespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12Namespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// SOAPDetail is the detail of a SOAP fault, carrying the application error code and the details of the error
+type SOAPDetail struct {
+	Code   string      `xml:"code"`
+	Fields []SOAPField `xml:"field"`
+}
+
+// SOAPField is a detail of the error in the detail of a SOAP fault
+type SOAPField struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type soap11Envelope struct {
+	XMLName xml.Name `xml:"soap:Envelope"`
+	XMLNS   string   `xml:"xmlns:soap,attr"`
+	Fault   struct {
+		FaultCode   string     `xml:"faultcode"`
+		FaultString string     `xml:"faultstring"`
+		Detail      SOAPDetail `xml:"detail"`
+	} `xml:"soap:Body>soap:Fault"`
+}
+
+type soap12Envelope struct {
+	XMLName xml.Name `xml:"env:Envelope"`
+	XMLNS   string   `xml:"xmlns:env,attr"`
+	Fault   struct {
+		Code   string `xml:"env:Code>env:Value"`
+		Reason struct {
+			Lang string `xml:"xml:lang,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"env:Reason>env:Text"`
+		Detail SOAPDetail `xml:"env:Detail"`
+	} `xml:"env:Body>env:Fault"`
+}
+
+// FormatSOAPFault returns the SOAP Fault envelope of the error for the SOAP version.
+// The faultcode derives from the status class, the faultstring from the message and the
+// detail from the code and the details of the error, omitted for the 5xx errors.
+// The fields of the error are internal and never sent.
+func FormatSOAPFault(err error, version string) ([]byte, error) {
+	client := Is4xx(err)
+	detail := soapDetail(err)
+	message := ErrorMessage(err)
+
+	var envelope interface{}
+	switch version {
+	case SOAP11:
+		soap11 := soap11Envelope{XMLNS: soap11Namespace}
+		soap11.Fault.FaultCode = "soap:Server"
+		if client {
+			soap11.Fault.FaultCode = "soap:Client"
+		}
+		soap11.Fault.FaultString = message
+		soap11.Fault.Detail = detail
+		envelope = soap11
+	case SOAP12:
+		soap12 := soap12Envelope{XMLNS: soap12Namespace}
+		soap12.Fault.Code = "env:Receiver"
+		if client {
+			soap12.Fault.Code = "env:Sender"
+		}
+		soap12.Fault.Reason.Lang = "en"
+		soap12.Fault.Reason.Text = message
+		soap12.Fault.Detail = detail
+		envelope = soap12
+	default:
+		return nil, fmt.Errorf("ergo: unsupported SOAP version %q", version)
+	}
+
+	body, marshalErr := xml.Marshal(envelope)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// WriteSOAPFault will write the SOAP Fault of the error to the response and report the error.
+// SOAP 1.1 faults are sent with a 500, SOAP 1.2 faults with a 400 when the client is at fault.
+func WriteSOAPFault(w http.ResponseWriter, err error, version string) error {
+	err = Identify(err)
+	body, formatErr := FormatSOAPFault(err, version)
+	if formatErr != nil {
+		return formatErr
+	}
+	status, contentType := http.StatusInternalServerError, "text/xml; charset=utf-8"
+	if version == SOAP12 {
+		contentType = "application/soap+xml; charset=utf-8"
+		if Is4xx(err) {
+			status = http.StatusBadRequest
+		}
+	}
+	writeBody(w, nil, policy(), err, status, contentType, body)
+	return nil
+}
+
+// soapDetail returns the detail of the SOAP fault, with the details of the error sorted
+// by name, masked for the 5xx errors
+func soapDetail(err error) SOAPDetail {
+	detail := SOAPDetail{Code: ErrorCode(err)}
+	if Is5xx(err) {
+		return detail
+	}
+	details := ErrorDetails(err)
+	for _, name := range sortedKeys(details) {
+		detail.Fields = append(detail.Fields, SOAPField{Name: name, Value: fmt.Sprint(details[name])})
+	}
+	return detail
+}
@@ -0,0 +1,57 @@
+package ergo
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSOAPFault(t *testing.T) {
+	err := &Error{
+		Code:    ENOTFOUND,
+		Message: "User not found.",
+		Details: map[string]interface{}{FieldResourceType: "user", FieldResourceID: 42},
+		Fields:  map[string]interface{}{"table": "users"},
+	}
+
+	// Test with SOAP 1.1
+	body, formatErr := FormatSOAPFault(err, SOAP11)
+	assert.NoError(t, formatErr)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><soap:Fault>`+
+		`<faultcode>soap:Client</faultcode><faultstring>User not found.</faultstring>`+
+		`<detail><code>not_found</code><field name="resource_id">42</field><field name="resource_type">user</field></detail>`+
+		`</soap:Fault></soap:Body></soap:Envelope>`, string(body))
+
+	// Test with SOAP 1.2, and a 5xx error whose details and fields are masked
+	internal := &Error{Code: EINTERNAL, Details: map[string]interface{}{"host": "db"}, Err: &Error{Fields: map[string]interface{}{"sql": "SELECT 1"}}}
+	body, _ = FormatSOAPFault(internal, SOAP12)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Body><env:Fault>`+
+		`<env:Code><env:Value>env:Receiver</env:Value></env:Code>`+
+		`<env:Reason><env:Text xml:lang="en">An internal error has occurred.</env:Text></env:Reason>`+
+		`<env:Detail><code>internal</code></env:Detail>`+
+		`</env:Fault></env:Body></env:Envelope>`, string(body))
+
+	// Test with an unsupported version
+	_, formatErr = FormatSOAPFault(err, "2.0")
+	assert.Error(t, formatErr)
+}
+
+func TestWriteSOAPFault(t *testing.T) {
+	err := &Error{Code: EINVALID}
+
+	// Test with SOAP 1.1
+	w := httptest.NewRecorder()
+	assert.NoError(t, WriteSOAPFault(w, err, SOAP11))
+	assert.Equal(t, 500, w.Code)
+	assert.Equal(t, "text/xml; charset=utf-8", w.Header().Get("Content-Type"))
+
+	// Test with SOAP 1.2
+	w = httptest.NewRecorder()
+	assert.NoError(t, WriteSOAPFault(w, err, SOAP12))
+	assert.Equal(t, 400, w.Code)
+	assert.Equal(t, "application/soap+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<env:Value>env:Sender</env:Value>")
+}
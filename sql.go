@@ -0,0 +1,123 @@
+package ergo
+
+import "strings"
+
+// FieldSQL is the field carrying the scrubbed SQL statement of a database error
+const FieldSQL = "sql"
+
+// CaptureSQL enables the capture of the SQL statements by WrapSQL
+var CaptureSQL = true
+
+// WrapSQL wraps the database error with the statement that has generated it, scrubbed
+// with ScrubSQL, in the internal fields. The code of the error is left unchanged.
+// Returns nil if err is nil.
+func WrapSQL(err error, query string) error {
+	if err == nil {
+		return nil
+	}
+	if !CaptureSQL {
+		return err
+	}
+	return &Error{
+		Err: err,
+		Fields: map[string]interface{}{
+			FieldSQL: ScrubSQL(query),
+		},
+	}
+}
+
+// ScrubSQL replaces the string and numeric literals of the statement with placeholders,
+// so that the values bound to it are not logged. Whitespace is collapsed.
+// String literals may escape their quotes by doubling them or with a backslash, and the
+// escape strings, e.g. E'a\'b', and the dollar-quoted strings of PostgreSQL, e.g. $$a'b$$
+// or $tag$a$$b$tag$, are scrubbed too.
+// An unterminated literal is scrubbed up to the end of the statement.
+func ScrubSQL(query string) string {
+	var buffer strings.Builder
+	runes := []rune(query)
+	space := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case (r == 'E' || r == 'e') && i+1 < len(runes) && runes[i+1] == '\'' && (i == 0 || !isIdentifier(runes[i-1])):
+			i = skipString(runes, i+1)
+			buffer.WriteByte('?')
+		case r == '\'':
+			i = skipString(runes, i)
+			buffer.WriteByte('?')
+		case r == '$' && dollarTag(runes, i) != "":
+			i = skipDollarString(runes, i, dollarTag(runes, i))
+			buffer.WriteByte('?')
+		case isDigit(r) && (i == 0 || !isIdentifier(runes[i-1])):
+			for i+1 < len(runes) && (isDigit(runes[i+1]) || runes[i+1] == '.') {
+				i++
+			}
+			buffer.WriteByte('?')
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if !space && buffer.Len() > 0 {
+				buffer.WriteByte(' ')
+			}
+			space = true
+			continue
+		default:
+			buffer.WriteRune(r)
+		}
+		space = false
+	}
+	return strings.TrimSpace(buffer.String())
+}
+
+// skipString returns the index of the quote ending the string literal starting at i,
+// doubled and backslash-escaped quotes included
+func skipString(runes []rune, i int) int {
+	for i++; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\':
+			i++
+		case runes[i] == '\'' && i+1 < len(runes) && runes[i+1] == '\'':
+			i++
+		case runes[i] == '\'':
+			return i
+		}
+	}
+	return len(runes)
+}
+
+// dollarTag returns the opening tag of the dollar-quoted string starting at i, e.g. $$ or
+// $tag$, or an empty string if there is none, e.g. for the placeholder $1
+func dollarTag(runes []rune, i int) string {
+	if i > 0 && isIdentifier(runes[i-1]) {
+		return ""
+	}
+	for j := i + 1; j < len(runes); j++ {
+		r := runes[j]
+		switch {
+		case r == '$':
+			return string(runes[i : j+1])
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (isDigit(r) && j > i+1):
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// skipDollarString returns the index of the last rune of the closing tag of the
+// dollar-quoted string starting at i
+func skipDollarString(runes []rune, i int, tag string) int {
+	body := string(runes[i+len([]rune(tag)):])
+	end := strings.Index(body, tag)
+	if end < 0 {
+		return len(runes)
+	}
+	return i + len([]rune(tag)) + len([]rune(body[:end])) + len([]rune(tag)) - 1
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// isIdentifier reports whether the rune can precede a digit in an identifier or a placeholder, e.g. users2 or $1
+func isIdentifier(r rune) bool {
+	return r == '_' || r == '$' || r == ':' || r == '@' || isDigit(r) || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
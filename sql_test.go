@@ -0,0 +1,42 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrubSQL(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM users2 WHERE email = ? AND age > ? AND id = $1",
+		ScrubSQL("SELECT *\n  FROM users2\n  WHERE email = 'j.o''doe@example.com' AND age > 42.5 AND id = $1"))
+	assert.Equal(t, "INSERT INTO t (a, b) VALUES (?, ?)", ScrubSQL("INSERT INTO t (a, b) VALUES ('x', 7)"))
+
+	// Test with backslash escapes
+	assert.Equal(t, "SELECT * FROM users WHERE name = ? AND id = ?",
+		ScrubSQL(`SELECT * FROM users WHERE name = 'O\'Brien secret' AND id = 7`))
+	assert.Equal(t, "SELECT * FROM users WHERE name = ? AND note = ?",
+		ScrubSQL(`SELECT * FROM users WHERE name = E'O\'Brien secret' AND note = e'a\\'`))
+
+	// Test with dollar-quoted strings
+	assert.Equal(t, "SELECT ?, ?, $1 FROM t",
+		ScrubSQL("SELECT $$it's a secret$$, $tag$a $$ secret$tag$, $1 FROM t"))
+	assert.Equal(t, "SELECT ?", ScrubSQL("SELECT $$unterminated secret"))
+	assert.Equal(t, "SELECT ?", ScrubSQL("SELECT 'unterminated secret"))
+}
+
+func TestWrapSQL(t *testing.T) {
+	assert.Nil(t, WrapSQL(nil, "SELECT 1"))
+
+	// Test with an application error
+	err := WrapSQL(&Error{Code: ECONFLICT}, "INSERT INTO users (email) VALUES ('jdoe@example.com')")
+	assert.Equal(t, ECONFLICT, ErrorCode(err))
+	assert.Equal(t, "INSERT INTO users (email) VALUES (?)", ErrorFields(err)[FieldSQL])
+	assert.Empty(t, FormatError(err).Details)
+
+	// Test with the capture disabled
+	defer func() { CaptureSQL = true }()
+	CaptureSQL = false
+	driverErr := errors.New("connection refused")
+	assert.Equal(t, driverErr, WrapSQL(driverErr, "SELECT 1"))
+}
@@ -0,0 +1,83 @@
+package ergo
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// Frame defines a frame of a call stack
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// ErrorStack returns the innermost call stack of the error chain, if available.
+// The chain is traversed through the wrapped errors, the Unwrap and the Cause methods,
+// and the stacks are read from the Stack of the application errors or from the
+// StackTrace method of the errors created with github.com/pkg/errors.
+func ErrorStack(err error) []Frame {
+	var stack []Frame
+	for err != nil {
+		if e, isCustomError := err.(*Error); isCustomError && len(e.Stack) > 0 {
+			stack = e.Stack
+		} else if frames := pkgStackTrace(err); len(frames) > 0 {
+			stack = frames
+		}
+		err = nextError(err)
+	}
+	return stack
+}
+
+// nextError returns the error wrapped by err, or nil
+func nextError(err error) error {
+	switch wrapper := err.(type) {
+	case *Error:
+		return wrapper.Err
+	case interface{ Unwrap() error }:
+		return wrapper.Unwrap()
+	case interface{ Cause() error }:
+		return wrapper.Cause()
+	}
+	return nil
+}
+
+// pkgStackTrace returns the frames of the StackTrace method of github.com/pkg/errors,
+// whose result is a slice of program counters, without depending on the package
+func pkgStackTrace(err error) []Frame {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil
+	}
+	methodType := method.Type()
+	if methodType.NumIn() != 0 || methodType.NumOut() != 1 {
+		return nil
+	}
+	trace := methodType.Out(0)
+	if trace.Kind() != reflect.Slice || trace.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	values := method.Call(nil)[0]
+	pcs := make([]uintptr, values.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(values.Index(i).Uint())
+	}
+	return callersFrames(pcs)
+}
+
+// callersFrames returns the frames of the program counters
+func callersFrames(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	var stack []Frame
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			return stack
+		}
+	}
+}
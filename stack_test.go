@@ -0,0 +1,67 @@
+package ergo
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pkgFrame and pkgStack have the shape of the Frame and StackTrace of github.com/pkg/errors
+type pkgFrame uintptr
+
+type pkgStack []pkgFrame
+
+type pkgError struct {
+	message string
+	stack   []uintptr
+}
+
+func newPkgError(message string) error {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	return &pkgError{message: message, stack: pcs[:n]}
+}
+
+func (e *pkgError) Error() string {
+	return e.message
+}
+
+func (e *pkgError) StackTrace() pkgStack {
+	stack := make(pkgStack, len(e.stack))
+	for i, pc := range e.stack {
+		stack[i] = pkgFrame(pc)
+	}
+	return stack
+}
+
+type pkgWithMessage struct {
+	cause error
+}
+
+func (w *pkgWithMessage) Error() string {
+	return "wrapped: " + w.cause.Error()
+}
+
+func (w *pkgWithMessage) Cause() error {
+	return w.cause
+}
+
+func TestErrorStack(t *testing.T) {
+	// Test without stack
+	assert.Nil(t, ErrorStack(errors.New("some error")))
+	assert.Nil(t, ErrorStack(nil))
+
+	// Test with a pkg/errors cause
+	err := &Error{Code: EINTERNAL, Err: &pkgWithMessage{cause: newPkgError("connection refused")}}
+	stack := ErrorStack(err)
+	assert.NotEmpty(t, stack)
+	assert.Equal(t, "github.com/skullflow/ergo.TestErrorStack", stack[0].Function)
+	assert.Contains(t, stack[0].File, "stack_test.go")
+
+	// Test with the stack of an application error
+	frames := []Frame{{Function: "main.main", File: "main.go", Line: 10}}
+	assert.Equal(t, frames, ErrorStack(fmt.Errorf("wrapped: %w", &Error{Code: EINTERNAL, Stack: frames})))
+}
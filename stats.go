@@ -0,0 +1,211 @@
+package ergo
+
+import (
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// StatsRecord defines the number of errors collected in a time window
+// Time is the start of the window
+// Fingerprint identifies errors sharing the same code and operation
+type StatsRecord struct {
+	Time        time.Time `json:"time"`
+	Code        string    `json:"code"`
+	Op          string    `json:"op"`
+	Cause       string    `json:"cause"`
+	Route       string    `json:"route"`
+	Fingerprint string    `json:"fingerprint"`
+	Count       int       `json:"count"`
+}
+
+type statsKey struct {
+	time  time.Time
+	code  string
+	op    string
+	cause string
+	route string
+}
+
+// DefaultStatsRetention is the Retention of the Stats returned by NewStats
+const DefaultStatsRetention = 24 * time.Hour
+
+// Stats collects the handled errors in fixed time windows.
+// The windows starting more than Retention before the current one are pruned when a
+// new window starts, so that the memory is bounded. They are kept when Retention is zero.
+// It is safe for concurrent use.
+type Stats struct {
+	Retention time.Duration
+
+	mu      sync.Mutex
+	window  time.Duration
+	latest  time.Time
+	records map[statsKey]*StatsRecord
+}
+
+// NewStats returns a Stats collecting errors in windows of the given duration,
+// retained for DefaultStatsRetention. It panics if the window is not positive.
+func NewStats(window time.Duration) *Stats {
+	if window <= 0 {
+		panic(fmt.Errorf("ergo: stats window %s is not positive", window))
+	}
+	return &Stats{
+		Retention: DefaultStatsRetention,
+		window:    window,
+		records:   make(map[statsKey]*StatsRecord),
+	}
+}
+
+// Record adds the error to the current window of the route.
+// Suppressed errors are ignored.
+func (s *Stats) Record(route string, err error) {
+	if err == nil || Suppressed(err) {
+		return
+	}
+	key := statsKey{
+		time:  now().UTC().Truncate(s.window),
+		code:  ErrorCode(err),
+		op:    errorOp(err),
+		cause: ErrorCause(err),
+		route: route,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key.time.After(s.latest) {
+		s.latest = key.time
+		s.prune()
+	}
+	record, ok := s.records[key]
+	if !ok {
+		record = &StatsRecord{
+			Time:        key.time,
+			Code:        key.code,
+			Op:          key.op,
+			Cause:       key.cause,
+			Route:       key.route,
+			Fingerprint: fingerprint(key.code, key.op),
+		}
+		s.records[key] = record
+	}
+	record.Count++
+}
+
+// prune removes the records of the windows older than the retention
+func (s *Stats) prune() {
+	if s.Retention <= 0 {
+		return
+	}
+	oldest := s.latest.Add(-s.Retention)
+	for key := range s.records {
+		if key.time.Before(oldest) {
+			delete(s.records, key)
+		}
+	}
+}
+
+// Records returns the collected records ordered by time, code, op, cause and route
+func (s *Stats) Records() []StatsRecord {
+	s.mu.Lock()
+	records := make([]StatsRecord, 0, len(s.records))
+	for _, record := range s.records {
+		records = append(records, *record)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		if a.Op != b.Op {
+			return a.Op < b.Op
+		}
+		if a.Cause != b.Cause {
+			return a.Cause < b.Cause
+		}
+		return a.Route < b.Route
+	})
+	return records
+}
+
+// ExportCSV writes the collected records as CSV, header included
+func (s *Stats) ExportCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"time", "code", "op", "cause", "route", "fingerprint", "count"})
+	for _, record := range s.Records() {
+		_ = writer.Write([]string{
+			record.Time.Format(time.RFC3339),
+			record.Code,
+			record.Op,
+			record.Cause,
+			record.Route,
+			record.Fingerprint,
+			strconv.Itoa(record.Count),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportNDJSON writes the collected records as newline delimited Json
+func (s *Stats) ExportNDJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range s.Records() {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errorOp returns the first operation found in the error stack, if any.
+func errorOp(err error) string {
+	if e, isCustomError := err.(*Error); isCustomError && e.Op != "" {
+		return e.Op
+	} else if isCustomError && e.Err != nil {
+		return errorOp(e.Err)
+	}
+	return ""
+}
+
+// fingerprint returns a short stable identifier of the code and operation
+func fingerprint(code, op string) string {
+	sum := sha1.Sum([]byte(code + "|" + op))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Counts returns the number of collected errors per code, across all the retained windows
+func (s *Stats) Counts() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]int)
+	for key, record := range s.records {
+		counts[key.code] += record.Count
+	}
+	return counts
+}
+
+// Publish exposes the error counts per code via expvar under the given name, and the
+// snapshot of the error policy, as returned by CurrentConfig, under the name suffixed
+// with "_config". As with expvar.Publish, it panics if a name is already registered.
+func (s *Stats) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return s.Counts()
+	}))
+	expvar.Publish(name+"_config", expvar.Func(func() interface{} {
+		return CurrentConfig()
+	}))
+}
@@ -0,0 +1,128 @@
+package ergo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsRecord(t *testing.T) {
+	stats := NewStats(time.Hour)
+
+	// Test with nil error
+	stats.Record("/users", nil)
+	assert.Empty(t, stats.Records())
+
+	stats.Record("/users", &Error{Code: ENOTFOUND, Op: "user.Get"})
+	stats.Record("/users", &Error{Op: "user.Get", Err: &Error{Code: ENOTFOUND}})
+	stats.Record("/users", errors.New("some error"))
+
+	records := stats.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, EINTERNAL, records[0].Code)
+	assert.Equal(t, 1, records[0].Count)
+	assert.Equal(t, ENOTFOUND, records[1].Code)
+	assert.Equal(t, "user.Get", records[1].Op)
+	assert.Equal(t, "/users", records[1].Route)
+	assert.Equal(t, 2, records[1].Count)
+	assert.Equal(t, fingerprint(ENOTFOUND, "user.Get"), records[1].Fingerprint)
+}
+
+func TestStatsRetention(t *testing.T) {
+	defer func() { DefaultClock = systemClock{} }()
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	DefaultClock = fixedClock(start)
+	stats := NewStats(time.Hour)
+	stats.Retention = 2 * time.Hour
+	stats.Record("/users", &Error{Code: ENOTFOUND})
+
+	// Test with a window within the retention
+	DefaultClock = fixedClock(start.Add(2 * time.Hour))
+	stats.Record("/users", &Error{Code: EINVALID})
+	assert.Len(t, stats.Records(), 2)
+
+	// Test with a window beyond the retention, pruning the first one
+	DefaultClock = fixedClock(start.Add(3 * time.Hour))
+	stats.Record("/users", &Error{Code: EINVALID})
+	records := stats.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, start.Add(2*time.Hour), records[0].Time)
+	assert.Equal(t, map[string]int{EINVALID: 2}, stats.Counts())
+
+	// Test with a late error of a past window, not pruning the current ones
+	DefaultClock = fixedClock(start)
+	stats.Record("/users", &Error{Code: ENOTFOUND})
+	assert.Len(t, stats.Records(), 3)
+}
+
+func TestNewStats(t *testing.T) {
+	// Test with an invalid window
+	assert.Panics(t, func() { NewStats(0) })
+	assert.Panics(t, func() { NewStats(-time.Minute) })
+	assert.Equal(t, DefaultStatsRetention, NewStats(time.Minute).Retention)
+}
+
+func TestStatsExportCSV(t *testing.T) {
+	stats := NewStats(time.Hour)
+	stats.Record("/users", &Error{Code: ENOTFOUND, Op: "user.Get"})
+
+	var buffer bytes.Buffer
+	err := stats.ExportCSV(&buffer)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "time,code,op,cause,route,fingerprint,count", lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], ",not_found,user.Get,unknown,/users,"+fingerprint(ENOTFOUND, "user.Get")+",1"))
+}
+
+func TestStatsExportNDJSON(t *testing.T) {
+	stats := NewStats(time.Hour)
+	stats.Record("/users", &Error{Code: ENOTFOUND, Op: "user.Get"})
+	stats.Record("/orders", &Error{Code: EINVALID, Op: "order.Create"})
+
+	var buffer bytes.Buffer
+	err := stats.ExportNDJSON(&buffer)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"code":"invalid"`)
+	assert.Contains(t, lines[1], `"route":"/users"`)
+}
+
+func TestStatsCounts(t *testing.T) {
+	stats := NewStats(time.Hour)
+	stats.Record("/users", &Error{Code: ENOTFOUND, Op: "user.Get"})
+	stats.Record("/orders", &Error{Code: ENOTFOUND, Op: "order.Get"})
+	stats.Record("/orders", &Error{Code: EINVALID})
+
+	expected := map[string]int{
+		ENOTFOUND: 2,
+		EINVALID:  1,
+	}
+	assert.Equal(t, expected, stats.Counts())
+}
+
+func TestStatsPublish(t *testing.T) {
+	stats := NewStats(time.Hour)
+	stats.Publish("ergo_test_errors")
+	stats.Record("/users", &Error{Code: ENOTFOUND})
+
+	actual := expvar.Get("ergo_test_errors").String()
+	assert.Equal(t, `{"not_found":1}`, actual)
+
+	// Test with the config snapshot
+	defer resetConfig()
+	assert.NoError(t, Update(Config{StackRates: map[string]float64{EINVALID: 0.5}}))
+	var config Config
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get("ergo_test_errors_config").String()), &config))
+	assert.Equal(t, map[string]float64{EINVALID: 0.5}, config.StackRates)
+	assert.Equal(t, CurrentConfig().Codes[ENOTFOUND], config.Codes[ENOTFOUND])
+}
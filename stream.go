@@ -0,0 +1,83 @@
+package ergo
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// NDJSONMediaType is the media type of the streams written by FrameWriter
+const NDJSONMediaType = "application/x-ndjson"
+
+// StreamFrame defines a frame of an NDJSON stream, carrying either data or an error
+// with the standard envelope. The terminal frame ends the stream.
+type StreamFrame struct {
+	Data     interface{} `json:"data,omitempty"`
+	Error    *JSONError  `json:"error,omitempty"`
+	Terminal bool        `json:"terminal,omitempty"`
+}
+
+// FrameWriter writes the frames of an NDJSON stream to the response, one per line,
+// flushing each of them. Non-terminal error frames express partial failures, e.g. an
+// item that could not be loaded, while the stream goes on. It is safe for concurrent use.
+type FrameWriter struct {
+	w http.ResponseWriter
+	r *http.Request
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewFrameWriter returns a FrameWriter writing the stream to the response of the request
+func NewFrameWriter(w http.ResponseWriter, r *http.Request) *FrameWriter {
+	header := w.Header()
+	header.Set("Content-Type", NDJSONMediaType)
+	header.Set("Cache-Control", "no-store")
+	return &FrameWriter{w: w, r: r}
+}
+
+// WriteData writes a data frame
+func (fw *FrameWriter) WriteData(data interface{}) error {
+	return fw.write(StreamFrame{Data: data})
+}
+
+// WriteError reports the error and writes an error frame, formatted with FormatErrorContext.
+// A terminal error frame ends the stream.
+func (fw *FrameWriter) WriteError(err error, terminal bool) error {
+	err = Identify(err)
+	report(err)
+	jsonError := FormatErrorContext(fw.r.Context(), err)
+	return fw.write(StreamFrame{Error: &jsonError, Terminal: terminal})
+}
+
+// Close ends the stream with a terminal frame, if it has not ended yet
+func (fw *FrameWriter) Close() error {
+	fw.mu.Lock()
+	closed := fw.closed
+	fw.mu.Unlock()
+	if closed {
+		return nil
+	}
+	return fw.write(StreamFrame{Terminal: true})
+}
+
+// write encodes the frame on a line and flushes it
+func (fw *FrameWriter) write(frame StreamFrame) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.closed {
+		return &Error{Code: EINTERNAL, Op: "ergo.FrameWriter", Message: "Stream has ended."}
+	}
+	line, err := json.Marshal(frame)
+	if err != nil {
+		return &Error{Code: EINTERNAL, Op: "ergo.FrameWriter", Err: err}
+	}
+	fw.closed = frame.Terminal
+	if _, err := fw.w.Write(append(line, '\n')); err != nil {
+		return &Error{Code: EINTERNAL, Op: "ergo.FrameWriter", Err: err}
+	}
+	if flusher, ok := fw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package ergo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameWriter(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	w := httptest.NewRecorder()
+	frames := NewFrameWriter(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	assert.NoError(t, frames.WriteData(map[string]int{"id": 1}))
+	assert.NoError(t, frames.WriteError(&Error{Code: ENOTFOUND, Message: "Item 2 not found."}, false))
+	assert.NoError(t, frames.WriteData(map[string]int{"id": 3}))
+	assert.NoError(t, frames.Close())
+
+	assert.Equal(t, NDJSONMediaType, w.Header().Get("Content-Type"))
+	assert.True(t, w.Flushed)
+	assert.Equal(t, `{"data":{"id":1}}
+{"error":{"code":"not_found","error_id":"err-1","code_id":3,"status_code":404,"message":"Item 2 not found."}}
+{"data":{"id":3}}
+{"terminal":true}
+`, w.Body.String())
+
+	// Test with a frame after the end of the stream
+	assert.Error(t, frames.WriteData(map[string]int{"id": 4}))
+	assert.NoError(t, frames.Close())
+
+	// Test with a terminal error frame
+	w = httptest.NewRecorder()
+	frames = NewFrameWriter(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+	assert.NoError(t, frames.WriteError(&Error{Code: EUNAVAILABLE}, true))
+	assert.NoError(t, frames.Close())
+	assert.Equal(t, `{"error":{"code":"unavailable","error_id":"err-1","code_id":15,"status_code":503,"message":"Service unavailable."},"terminal":true}
+`, w.Body.String())
+}
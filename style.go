@@ -0,0 +1,129 @@
+package ergo
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Enforcements of the MessagePolicy
+const (
+	MessageFix    = iota // Rewrite the messages to follow the policy
+	MessageReport        // Send the messages as is and report a diagnostic, e.g. in production
+	MessagePanic         // Panic when formatting a message not following the policy, e.g. in tests
+)
+
+// MessagePolicy defines the style of the messages sent to the clients.
+// SentenceCase requires an uppercase first letter and TrailingPeriod a final punctuation mark.
+// MaxLength is the maximum number of characters, if positive: MessageFix truncates the longer
+// messages with an ellipsis. ForbiddenWords lists the words, matched case-insensitively, the
+// messages must not contain, e.g. "exception" or "null": MessageFix replaces those messages
+// with the message of their code.
+type MessagePolicy struct {
+	SentenceCase   bool
+	TrailingPeriod bool
+	MaxLength      int
+	ForbiddenWords []string
+	Enforcement    int
+}
+
+// MessageStyle is the policy applied to the messages of the errors formatted by the package.
+// Messages are sent as is when it is nil.
+var MessageStyle *MessagePolicy
+
+// Violations returns the descriptions of the rules of the policy the message does not follow
+func (p *MessagePolicy) Violations(message string) []string {
+	var violations []string
+	if p.SentenceCase && !sentenceCase(message) {
+		violations = append(violations, "message must start with an uppercase letter")
+	}
+	if p.TrailingPeriod && !endsSentence(message) {
+		violations = append(violations, "message must end with a period")
+	}
+	if p.MaxLength > 0 && utf8.RuneCountInString(message) > p.MaxLength {
+		violations = append(violations, fmt.Sprintf("message must not exceed %d characters", p.MaxLength))
+	}
+	if word := p.forbiddenWord(message); word != "" {
+		violations = append(violations, fmt.Sprintf("message must not contain %q", word))
+	}
+	return violations
+}
+
+// Apply returns the message rewritten to follow the policy, falling back to fallback
+// when it contains a forbidden word
+func (p *MessagePolicy) Apply(message string, fallback string) string {
+	if p.forbiddenWord(message) != "" {
+		message = fallback
+	}
+	if p.SentenceCase && !sentenceCase(message) {
+		first, size := utf8.DecodeRuneInString(message)
+		message = string(unicode.ToUpper(first)) + message[size:]
+	}
+	if p.TrailingPeriod && !endsSentence(message) {
+		message += "."
+	}
+	if p.MaxLength > 0 && utf8.RuneCountInString(message) > p.MaxLength {
+		runes := []rune(message)
+		message = strings.TrimRightFunc(string(runes[:p.MaxLength-1]), unicode.IsSpace) + "…"
+	}
+	return message
+}
+
+// forbiddenWord returns the first forbidden word of the message, or an empty string
+func (p *MessagePolicy) forbiddenWord(message string) string {
+	words := strings.FieldsFunc(strings.ToLower(message), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	for _, forbidden := range p.ForbiddenWords {
+		for _, word := range words {
+			if word == strings.ToLower(forbidden) {
+				return forbidden
+			}
+		}
+	}
+	return ""
+}
+
+// sentenceCase reports whether the message starts with an uppercase letter or a non-letter
+func sentenceCase(message string) bool {
+	first, _ := utf8.DecodeRuneInString(message)
+	return !unicode.IsLetter(first) || unicode.IsUpper(first)
+}
+
+// endsSentence reports whether the message ends with a punctuation mark or an ellipsis
+func endsSentence(message string) bool {
+	last, _ := utf8.DecodeLastRuneInString(message)
+	return strings.ContainsRune(".!?…", last)
+}
+
+// styleError applies the MessageStyle to the message of the formatted error, once
+// the message is final
+func styleError(p *Config, jsonError JSONError) JSONError {
+	jsonError.Message = styleMessage(p, jsonError.Code, jsonError.Message)
+	return jsonError
+}
+
+// styleMessage applies the MessageStyle to the message of an error with the code
+func styleMessage(p *Config, code string, message string) string {
+	if MessageStyle == nil || message == "" {
+		return message
+	}
+	violations := MessageStyle.Violations(message)
+	if len(violations) == 0 {
+		return message
+	}
+	switch MessageStyle.Enforcement {
+	case MessageFix:
+		return MessageStyle.Apply(message, errorMessage(p, &Error{Code: code}))
+	case MessagePanic:
+		panic(fmt.Errorf("ergo: message %q: %s", message, strings.Join(violations, ", ")))
+	}
+	OnDiagnostic(&Error{
+		Code:    EINTERNAL,
+		Op:      "ergo.FormatError",
+		Err:     fmt.Errorf("message %q: %s", message, strings.Join(violations, ", ")),
+		Details: map[string]interface{}{"code": code},
+	})
+	return message
+}
@@ -0,0 +1,73 @@
+package ergo
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessagePolicy(t *testing.T) {
+	policy := &MessagePolicy{SentenceCase: true, TrailingPeriod: true, MaxLength: 20, ForbiddenWords: []string{"null"}}
+
+	// Test with the violations
+	assert.Empty(t, policy.Violations("Email is invalid."))
+	assert.Equal(t, []string{
+		"message must start with an uppercase letter",
+		"message must end with a period",
+		"message must not exceed 20 characters",
+		`message must not contain "null"`,
+	}, policy.Violations("user id is NULL in the request"))
+
+	// Test with the rewritten messages
+	assert.Equal(t, "Email is invalid.", policy.Apply("email is invalid", "Bad request."))
+	assert.Equal(t, "The request body is…", policy.Apply("The request body is too large", "Bad request."))
+	assert.Equal(t, "Bad request.", policy.Apply("user id is null", "Bad request."))
+}
+
+func TestMessageStyle(t *testing.T) {
+	defer func() {
+		MessageStyle = nil
+	}()
+	MessageStyle = &MessagePolicy{SentenceCase: true, TrailingPeriod: true}
+
+	// Test with the messages fixed at serialization
+	assert.Equal(t, "Email is invalid.", FormatError(&Error{Code: EINVALID, Message: "email is invalid"}).Message)
+
+	// Test with the violations reported
+	defer func(onDiagnostic func(error)) {
+		OnDiagnostic = onDiagnostic
+	}(OnDiagnostic)
+	var diagnostics []error
+	OnDiagnostic = func(diagnostic error) {
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	MessageStyle.Enforcement = MessageReport
+	assert.Equal(t, "email is invalid", FormatError(&Error{Code: EINVALID, Message: "email is invalid"}).Message)
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, `ergo.FormatError: message "email is invalid": message must start with an uppercase letter, message must end with a period`, diagnostics[0].Error())
+
+	// Test that a write reports the violations once
+	diagnostics = nil
+	WriteError(httptest.NewRecorder(), &Error{Code: EINVALID, Message: "email is invalid"})
+	assert.Len(t, diagnostics, 1)
+
+	// Test with the style applied to the message of the tenant
+	defer func() { TenantPolicies = map[string]TenantPolicy{} }()
+	TenantPolicies["acme"] = TenantPolicy{Messages: map[string]string{EINVALID: "check the email"}}
+	diagnostics = nil
+	ctx := WithTenant(context.Background(), "acme")
+	assert.Equal(t, "check the email", FormatErrorContext(ctx, &Error{Code: EINVALID, Message: "Email is invalid."}).Message)
+	assert.Len(t, diagnostics, 1)
+	MessageStyle.Enforcement = MessageFix
+	assert.Equal(t, "Check the email.", FormatErrorContext(ctx, &Error{Code: EINVALID, Message: "email is invalid"}).Message)
+
+	// Test with the violations panicking
+	MessageStyle.Enforcement = MessagePanic
+	assert.Panics(t, func() {
+		FormatError(errors.New("boom"))
+		FormatError(&Error{Code: EINVALID, Message: "email is invalid"})
+	})
+}
@@ -0,0 +1,62 @@
+package ergo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// Summary returns a compact single-token summary of the error for the access logs,
+// the code followed by the innermost operation of the stack, e.g. "invalid/user.Create",
+// or only the code when no error of the stack has an operation.
+func Summary(err error) string {
+	if err == nil {
+		return ""
+	}
+	ops := errorOps(err)
+	if len(ops) == 0 {
+		return ErrorCode(err)
+	}
+	return ErrorCode(err) + "/" + ops[len(ops)-1]
+}
+
+// summary holds the summary of the error served for a request
+type summary struct {
+	mu    sync.Mutex
+	value string
+}
+
+type summaryKey struct{}
+
+// Summarize is a middleware storing in the context of the request the Summary of the error
+// served with ServeError or WriteErrorContext, for the access logger wrapped by it to read
+// with ErrorSummary once the request is served.
+func Summarize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), summaryKey{}, &summary{})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ErrorSummary returns the summary of the error served for the request of the context,
+// or an empty string if no error was served
+func ErrorSummary(ctx context.Context) string {
+	holder, ok := ctx.Value(summaryKey{}).(*summary)
+	if !ok {
+		return ""
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	return holder.value
+}
+
+// setSummary stores the summary of the error in the context, if it holds one
+func setSummary(ctx context.Context, err error) {
+	holder, ok := ctx.Value(summaryKey{}).(*summary)
+	if !ok {
+		return
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	holder.value = Summary(err)
+}
@@ -0,0 +1,42 @@
+package ergo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummary(t *testing.T) {
+	// Test with the innermost operation
+	err := &Error{Op: "handler.CreateUser", Err: &Error{Code: EINVALID, Op: "user.Create"}}
+	assert.Equal(t, "invalid/user.Create", Summary(err))
+
+	// Test without operation
+	assert.Equal(t, "internal", Summary(errors.New("boom")))
+	assert.Equal(t, "", Summary(nil))
+}
+
+func TestSummarize(t *testing.T) {
+	var logged string
+	accessLog := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			logged = ErrorSummary(r.Context())
+		})
+	}
+	handler := Summarize(accessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users" {
+			ServeError(w, r, &Error{Code: ENOTFOUND, Op: "user.Get"})
+		}
+	})))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, "not_found/user.Get", logged)
+
+	// Test with a request served without error
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, "", logged)
+}
@@ -0,0 +1,20 @@
+package ergo
+
+// Support defines the contacts sent with the server errors, for the users to report them
+type Support struct {
+	Email     string `json:"email,omitempty"`
+	StatusURL string `json:"status_url,omitempty"`
+	DocsURL   string `json:"docs_url,omitempty"`
+}
+
+// DefaultSupport is the support block sent under "support" with the 5xx errors.
+// The block is omitted when it is nil. Tenant policies can override it.
+var DefaultSupport *Support
+
+// errorSupport returns the support block of the response of the error with the status code
+func errorSupport(err error, status int, support *Support) *Support {
+	if err == nil || status < 500 {
+		return nil
+	}
+	return support
+}
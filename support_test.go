@@ -0,0 +1,29 @@
+package ergo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupport(t *testing.T) {
+	defer func() {
+		DefaultSupport = nil
+		TenantPolicies = map[string]TenantPolicy{}
+	}()
+	DefaultSupport = &Support{Email: "support@example.test", StatusURL: "https://status.example.test"}
+	TenantPolicies["acme"] = TenantPolicy{Support: &Support{Email: "help@acme.test"}}
+
+	// Test with a server error
+	assert.Equal(t, DefaultSupport, FormatError(&Error{Code: EUNAVAILABLE}).Support)
+	assert.Equal(t, DefaultSupport, FormatErrorContext(context.Background(), &Error{Code: EINTERNAL}).Support)
+
+	// Test with a client error
+	assert.Nil(t, FormatError(&Error{Code: EINVALID}).Support)
+
+	// Test with a tenant override
+	ctx := WithTenant(context.Background(), "acme")
+	assert.Equal(t, &Support{Email: "help@acme.test"}, FormatErrorContext(ctx, &Error{Code: EINTERNAL}).Support)
+	assert.Nil(t, FormatErrorContext(ctx, &Error{Code: ENOTFOUND}).Support)
+}
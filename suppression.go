@@ -0,0 +1,56 @@
+package ergo
+
+import (
+	"sync"
+	"time"
+)
+
+// SuppressedReporters receive the handled errors suppressed by a window,
+// e.g. a logger at debug level, instead of the Reporters.
+var SuppressedReporters []Reporter
+
+// suppressionWindow defines a time range during which the matching errors are suppressed
+type suppressionWindow struct {
+	start time.Time
+	end   time.Time
+	match Matcher
+}
+
+var (
+	suppressionMu      sync.Mutex
+	suppressionWindows []suppressionWindow
+)
+
+// Suppress registers a window, e.g. a planned maintenance of a dependency, during which
+// the matching errors are sent to SuppressedReporters instead of the Reporters and are
+// not counted by Stats nor against the error Budget.
+func Suppress(start time.Time, end time.Time, match Matcher) {
+	suppressionMu.Lock()
+	defer suppressionMu.Unlock()
+	suppressionWindows = append(suppressionWindows, suppressionWindow{start: start, end: end, match: match})
+}
+
+// Suppressed reports whether the error matches a window in progress, or is an error of
+// the maintenance mode, tagged with TagMaintenance
+func Suppressed(err error) bool {
+	if MatchTag(TagMaintenance)(err) {
+		return true
+	}
+	now := now()
+
+	suppressionMu.Lock()
+	defer suppressionMu.Unlock()
+	suppressed := false
+	active := suppressionWindows[:0]
+	for _, window := range suppressionWindows {
+		if now.After(window.end) {
+			continue
+		}
+		active = append(active, window)
+		if !now.Before(window.start) && window.match(err) {
+			suppressed = true
+		}
+	}
+	suppressionWindows = active
+	return suppressed
+}
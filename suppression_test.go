@@ -0,0 +1,45 @@
+package ergo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppress(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	defer func() {
+		suppressionWindows = nil
+		Reporters = nil
+		SuppressedReporters = nil
+	}()
+	now := time.Now()
+	Suppress(now.Add(-time.Minute), now.Add(time.Hour), MatchOpPrefix("payments."))
+	Suppress(now.Add(time.Hour), now.Add(2*time.Hour), MatchCode(ENOTFOUND))
+	Suppress(now.Add(-time.Hour), now.Add(-time.Minute), MatchCode(EINVALID))
+
+	payments := &Error{Code: EINTERNAL, Op: "payments.Charge"}
+	assert.True(t, Suppressed(payments))
+	assert.False(t, Suppressed(&Error{Code: ENOTFOUND}))
+	assert.False(t, Suppressed(&Error{Code: EINVALID}))
+	// The expired window is removed
+	assert.Len(t, suppressionWindows, 2)
+
+	alerts, debug := &captureReporter{}, &captureReporter{}
+	Reporters = []Reporter{alerts}
+	SuppressedReporters = []Reporter{debug}
+	other := &Error{Code: EINTERNAL, Op: "users.Get"}
+	HandleError(payments)
+	HandleError(other)
+	assert.Equal(t, []error{&Error{Err: other, ErrorID: "err-1"}}, alerts.reported())
+	assert.Equal(t, []error{&Error{Err: payments, ErrorID: "err-1"}}, debug.reported())
+
+	stats := NewStats(time.Hour)
+	stats.Record("/charges", payments)
+	assert.Empty(t, stats.Records())
+
+	budget := NewBudget()
+	budget.Record("payments", "/charges", payments)
+	assert.Equal(t, 0, budget.Counts()[0].Impacting)
+}
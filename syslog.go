@@ -0,0 +1,84 @@
+package ergo
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Syslog severities used for the handled errors
+const (
+	syslogSeverityError   = 3
+	syslogSeverityWarning = 4
+)
+
+// SyslogLocal0 is the local0 syslog facility
+const SyslogLocal0 = 16
+
+// syslogSDID identifies the structured data element of the errors
+const syslogSDID = "ergo@32473"
+
+// Syslog is a Reporter writing the handled errors as RFC 5424 messages,
+// with code, error_id, op, status and cause in the structured data, e.g. to a net.Conn
+// dialed to the syslog daemon. Server errors have severity err, the others warning.
+// It is safe for concurrent use.
+type Syslog struct {
+	Writer   io.Writer
+	Facility int
+	Hostname string
+	AppName  string
+
+	mu sync.Mutex
+}
+
+// NewSyslog returns a Syslog writing to w with facility local0 and the hostname of the machine
+func NewSyslog(w io.Writer, appName string) *Syslog {
+	hostname, _ := os.Hostname()
+	return &Syslog{
+		Writer:   w,
+		Facility: SyslogLocal0,
+		Hostname: hostname,
+		AppName:  appName,
+	}
+}
+
+// Report writes the RFC 5424 message of the error
+func (s *Syslog) Report(err error) {
+	severity := syslogSeverityWarning
+	if ErrorStatusCode(err) >= http.StatusInternalServerError {
+		severity = syslogSeverityError
+	}
+	message := fmt.Sprintf("<%d>1 %s %s %s %d - [%s code=\"%s\" error_id=\"%s\" op=\"%s\" status=\"%d\" cause=\"%s\"] %s\n",
+		s.Facility*8+severity,
+		now().UTC().Format(time.RFC3339Nano),
+		syslogHeader(s.Hostname),
+		syslogHeader(s.AppName),
+		os.Getpid(),
+		syslogSDID,
+		escapeSDParam(ErrorCode(err)),
+		escapeSDParam(eventID(err)),
+		escapeSDParam(errorOp(err)),
+		ErrorStatusCode(err),
+		escapeSDParam(ErrorCause(err)),
+		err.Error(),
+	)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = io.WriteString(s.Writer, message)
+}
+
+// syslogHeader returns the NILVALUE for empty header fields
+func syslogHeader(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// escapeSDParam escapes the characters not allowed in the values of the structured data
+var escapeSDParam = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace
@@ -0,0 +1,28 @@
+package ergo
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyslog(t *testing.T) {
+	var buffer bytes.Buffer
+	syslog := NewSyslog(&buffer, "billing")
+	syslog.Hostname = "host1"
+
+	syslog.Report(&Error{Code: ENOTFOUND, Op: "invoice.Get", Message: `say "hi"]`, ErrorID: "err-1"})
+	pattern := regexp.MustCompile(`^<132>1 \S+ host1 billing \d+ - \[ergo@32473 code="not_found" error_id="err-1" op="invoice.Get" status="404" cause="unknown"\] invoice.Get: <not_found>say "hi"\]` + "\n$")
+	assert.Regexp(t, pattern, buffer.String())
+
+	// Test with server error and escaping
+	buffer.Reset()
+	syslog.AppName = ""
+	syslog.Report(&Error{Op: `db."Query"`, Err: errors.New("connection refused")})
+	assert.Contains(t, buffer.String(), "<131>1 ")
+	assert.Contains(t, buffer.String(), " host1 - ")
+	assert.Contains(t, buffer.String(), `op="db.\"Query\""`)
+}
@@ -0,0 +1,74 @@
+package ergo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LegacyRule maps the legacy errors matching Match to an application error code,
+// with the message sent to the client, the one of the code if empty.
+type LegacyRule struct {
+	Match   Matcher
+	Code    string
+	Message string
+}
+
+// Taxonomy maps a legacy set of errors to application error codes, to adopt the
+// package incrementally in a codebase returning its own errors.
+type Taxonomy []LegacyRule
+
+// MatchSentinel matches the errors wrapping target, as reported by errors.Is
+func MatchSentinel(target error) Matcher {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// MatchMessage matches the errors whose string representation contains substr
+func MatchMessage(substr string) Matcher {
+	return func(err error) bool {
+		return strings.Contains(err.Error(), substr)
+	}
+}
+
+// Wrap wraps the error with the code of the first matching rule. Application errors,
+// and errors matching no rule, are returned unchanged.
+func (t Taxonomy) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, isCustomError := err.(*Error); isCustomError {
+		return err
+	}
+	for _, rule := range t {
+		if rule.Match(err) {
+			message := rule.Message
+			if message == "" {
+				// The message of the legacy error must not reach the client
+				message = policy().Codes[rule.Code].Message
+			}
+			return &Error{Code: rule.Code, Message: message, Err: err}
+		}
+	}
+	return err
+}
+
+// Validate reports the problems of the rules, e.g. at startup alongside Config.Validate:
+// rules without matcher, and codes unknown to the registry
+func (t Taxonomy) Validate() error {
+	codes := policy().Codes
+	var problems []string
+	for i, rule := range t {
+		if rule.Match == nil {
+			problems = append(problems, fmt.Sprintf("rule %d has no matcher", i))
+		}
+		if _, ok := codes[rule.Code]; !ok {
+			problems = append(problems, fmt.Sprintf("rule %d references the unknown code %s", i, rule.Code))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("ergo: invalid taxonomy: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
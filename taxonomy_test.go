@@ -0,0 +1,44 @@
+package ergo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxonomy(t *testing.T) {
+	errNoRows := errors.New("sql: no rows in result set")
+	taxonomy := Taxonomy{
+		{Match: MatchSentinel(errNoRows), Code: ENOTFOUND},
+		{Match: MatchMessage("duplicate key"), Code: ECONFLICT, Message: "Already exists."},
+	}
+
+	// Test with a wrapped sentinel
+	legacy := fmt.Errorf("get user: %w", errNoRows)
+	err := taxonomy.Wrap(legacy)
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	assert.Equal(t, "Resource not found.", ErrorMessage(err))
+	assert.Equal(t, legacy, err.(*Error).Err)
+
+	// Test with a message
+	err = taxonomy.Wrap(errors.New(`pq: duplicate key value violates unique constraint "users_email"`))
+	assert.Equal(t, ECONFLICT, ErrorCode(err))
+	assert.Equal(t, "Already exists.", ErrorMessage(err))
+
+	// Test with unmapped and application errors
+	legacy = errors.New("some error")
+	assert.Equal(t, legacy, taxonomy.Wrap(legacy))
+	applicationErr := &Error{Code: EINVALID, Err: errNoRows}
+	assert.Equal(t, applicationErr, taxonomy.Wrap(applicationErr))
+	assert.Nil(t, taxonomy.Wrap(nil))
+}
+
+func TestTaxonomyValidate(t *testing.T) {
+	assert.NoError(t, Taxonomy{{Match: MatchMessage("duplicate key"), Code: ECONFLICT}}.Validate())
+
+	// Test with invalid matchers
+	err := Taxonomy{{Code: ECONFLICT}, {Match: MatchMessage("timeout"), Code: "time_out"}}.Validate()
+	assert.EqualError(t, err, "ergo: invalid taxonomy: rule 0 has no matcher; rule 1 references the unknown code time_out")
+}
@@ -0,0 +1,111 @@
+package ergo
+
+import (
+	"context"
+	"strings"
+)
+
+// Template defines the defaults of the errors created within an endpoint group
+// OpPrefix is prepended to the operation of the errors
+// Tags are added to the tags of the errors
+// DocsURL is the base of the documentation URL of the codes, sent as docs_url in the details
+// Config overrides the parts of the error policy it sets, e.g. the registry, the Cache-Control
+// headers or the tenant policies, for the errors formatted with the context of the template.
+// The stack rates apply when the errors are constructed, so they are not scoped.
+type Template struct {
+	OpPrefix string
+	Tags     []string
+	DocsURL  string
+	Config   *Config
+}
+
+type templateKey struct{}
+
+// WithTemplate returns a copy of the context carrying the template of the endpoint group
+func WithTemplate(ctx context.Context, template Template) context.Context {
+	return context.WithValue(ctx, templateKey{}, template)
+}
+
+// ApplyTemplate applies the template of the context, if any, to the error and returns it
+func ApplyTemplate(ctx context.Context, err *Error) *Error {
+	template, ok := ctx.Value(templateKey{}).(Template)
+	if !ok || err == nil {
+		return err
+	}
+	return template.Apply(err)
+}
+
+// Apply applies the template to the error and returns it.
+// The op is prefixed unless it is the OpPrefix or one of its dotted children.
+func (t Template) Apply(err *Error) *Error {
+	err = err.own()
+	if t.OpPrefix != "" && err.Op != t.OpPrefix && !strings.HasPrefix(err.Op, t.OpPrefix+".") {
+		if err.Op == "" {
+			err.Op = t.OpPrefix
+		} else {
+			err.Op = t.OpPrefix + "." + err.Op
+		}
+	}
+	if len(t.Tags) > 0 {
+		err.Tags = append(append([]string{}, err.Tags...), t.Tags...)
+	}
+	if t.DocsURL != "" {
+		err = err.setDetail("docs_url", codeDocsURL(t.DocsURL, ErrorCode(err)))
+	}
+	return err
+}
+
+// codeDocsURL returns the documentation URL of the code under the base URL
+func codeDocsURL(base string, code string) string {
+	return strings.TrimSuffix(base, "/") + "/" + code
+}
+
+// TemplateOption adds a scope default to a child template
+type TemplateOption func(t *Template)
+
+// WithOpPrefix appends the prefix to the operation prefix of the parent template
+func WithOpPrefix(prefix string) TemplateOption {
+	return func(t *Template) {
+		if t.OpPrefix == "" {
+			t.OpPrefix = prefix
+		} else {
+			t.OpPrefix += "." + prefix
+		}
+	}
+}
+
+// WithTag adds the tag to the tags of the parent template
+func WithTag(tag string) TemplateOption {
+	return func(t *Template) {
+		t.Tags = append(t.Tags, tag)
+	}
+}
+
+// WithDocsURL overrides the documentation URL of the parent template
+func WithDocsURL(docsURL string) TemplateOption {
+	return func(t *Template) {
+		t.DocsURL = docsURL
+	}
+}
+
+// WithConfig overrides the parts of the config of the parent template the config sets
+func WithConfig(config Config) TemplateOption {
+	return func(t *Template) {
+		if t.Config == nil {
+			t.Config = &config
+		} else {
+			t.Config = t.Config.merge(config)
+		}
+	}
+}
+
+// Child returns a template inheriting the defaults and the config of t, with the scope
+// defaults of the options, e.g. for a bounded context of a monolith. The template t is not modified.
+func (t Template) Child(options ...TemplateOption) Template {
+	child := t
+	child.Tags = append([]string{}, t.Tags...)
+	for _, option := range options {
+		option(&child)
+	}
+	return child
+}
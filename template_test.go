@@ -0,0 +1,80 @@
+package ergo
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTemplate(t *testing.T) {
+	// Test without template
+	err := ApplyTemplate(context.Background(), &Error{Code: EINVALID, Op: "Create"})
+	assert.Equal(t, &Error{Code: EINVALID, Op: "Create"}, err)
+
+	ctx := WithTemplate(context.Background(), Template{
+		OpPrefix: "billing",
+		Tags:     []string{"billing"},
+		DocsURL:  "https://docs.example.com/errors/",
+	})
+	err = ApplyTemplate(ctx, &Error{Code: EINVALID, Op: "invoice.Create", Tags: []string{"critical"}})
+	assert.Equal(t, "billing.invoice.Create", err.Op)
+	assert.Equal(t, []string{"critical", "billing"}, err.Tags)
+	assert.Equal(t, "https://docs.example.com/errors/invalid", err.Details["docs_url"])
+
+	// Test with an error already prefixed and without Op
+	err = ApplyTemplate(ctx, &Error{Op: "billing.Charge"})
+	assert.Equal(t, "billing.Charge", err.Op)
+	err = ApplyTemplate(ctx, &Error{Op: "billing"})
+	assert.Equal(t, "billing", err.Op)
+
+	// Test with an op sharing the prefix without being a child of it
+	err = ApplyTemplate(ctx, &Error{Op: "billingx.Charge"})
+	assert.Equal(t, "billing.billingx.Charge", err.Op)
+	err = ApplyTemplate(ctx, FieldRequired("amount"))
+	assert.Equal(t, "billing", err.Op)
+	assert.Equal(t, "amount", err.Details["field"])
+}
+
+func TestTemplateChild(t *testing.T) {
+	parent := Template{Tags: []string{"monolith"}, DocsURL: "https://docs.example.com/errors"}
+	billing := parent.Child(WithOpPrefix("billing"), WithTag("billing"))
+	invoices := billing.Child(WithOpPrefix("invoices"), WithDocsURL("https://docs.example.com/billing/errors"))
+
+	assert.Equal(t, Template{Tags: []string{"monolith"}, DocsURL: "https://docs.example.com/errors"}, parent)
+	assert.Equal(t, Template{OpPrefix: "billing", Tags: []string{"monolith", "billing"}, DocsURL: "https://docs.example.com/errors"}, billing)
+	assert.Equal(t, Template{OpPrefix: "billing.invoices", Tags: []string{"monolith", "billing"}, DocsURL: "https://docs.example.com/billing/errors"}, invoices)
+
+	err := invoices.Apply(&Error{Code: ENOTFOUND, Op: "Get"})
+	assert.Equal(t, "billing.invoices.Get", err.Op)
+	assert.Equal(t, []string{"monolith", "billing"}, err.Tags)
+}
+
+func TestTemplateChildConfig(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	codes := Mapping()
+	codes[ENOTFOUND] = CodeInfo{ID: 3, Status: 410, Message: "Invoice not found."}
+	billing := Template{}.Child(WithConfig(Config{CacheControl: map[string]string{ENOTFOUND: "max-age=60"}}))
+	invoices := billing.Child(WithConfig(Config{
+		Codes:          codes,
+		TenantPolicies: map[string]TenantPolicy{"acme": {Messages: map[string]string{ENOTFOUND: "Acme invoice not found."}}},
+	}))
+	assert.Equal(t, &Config{CacheControl: map[string]string{ENOTFOUND: "max-age=60"}}, billing.Config)
+
+	// Test with the config inherited from the parent template
+	recorder := httptest.NewRecorder()
+	WriteErrorContext(WithTemplate(context.Background(), invoices), recorder, &Error{Code: ENOTFOUND})
+	assert.Equal(t, 410, recorder.Code)
+	assert.Equal(t, "max-age=60", recorder.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"code":"not_found","error_id":"err-1","code_id":3,"status_code":410,"message":"Invoice not found."}`, recorder.Body.String())
+
+	ctx := WithTenant(WithTemplate(context.Background(), invoices), "acme")
+	assert.Equal(t, "Acme invoice not found.", FormatErrorContext(ctx, &Error{Code: ENOTFOUND}).Message)
+
+	// Test that the policy of the package is not modified
+	recorder = httptest.NewRecorder()
+	WriteErrorContext(WithTenant(context.Background(), "acme"), recorder, &Error{Code: ENOTFOUND})
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, DefaultCacheControl, recorder.Header().Get("Cache-Control"))
+}
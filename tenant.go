@@ -0,0 +1,84 @@
+package ergo
+
+import "context"
+
+// TenantPolicy defines the overrides applied to the errors sent to a tenant
+// Messages overrides the message sent for each code
+// Details are added to the details of every error
+// Redact omits the details and the custom messages of the errors
+// Support overrides the DefaultSupport block of the 5xx errors
+// Locale is the locale of the Messages, sent as the locale detail
+// DocsURL is the base of the documentation URL of the codes, sent as docs_url in the details
+type TenantPolicy struct {
+	Messages map[string]string      `json:"messages,omitempty"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+	Redact   bool                   `json:"redact,omitempty"`
+	Support  *Support               `json:"support,omitempty"`
+	Locale   string                 `json:"locale,omitempty"`
+	DocsURL  string                 `json:"docs_url,omitempty"`
+}
+
+// TenantPolicies maps the tenant identifiers to their policy
+var TenantPolicies = map[string]TenantPolicy{}
+
+// ResolveTenant returns the tenant identifier of the context.
+// By default it returns the tenant set with WithTenant.
+var ResolveTenant = func(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of the context carrying the tenant identifier
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// FormatErrorContext works like FormatError, applying the config of the template, the policy
+// of the tenant, the feature flags and the audience of the context, and encrypting the
+// internal details
+func FormatErrorContext(ctx context.Context, err error) JSONError {
+	return formatErrorContext(contextPolicy(ctx), ctx, err)
+}
+
+// formatErrorContext formats the error for the context under the policy.
+// The MessageStyle is applied once the tenant overrides are applied.
+func formatErrorContext(p *Config, ctx context.Context, err error) JSONError {
+	jsonError := formatError(p, err)
+	jsonError.StatusCode = errorStatusCodeContext(p, ctx, err)
+	jsonError.Support = errorSupport(err, jsonError.StatusCode, DefaultSupport)
+	jsonError.Message = errorMessageContext(p, ctx, err)
+	if err != nil && len(EncryptionKey) > 0 {
+		jsonError.Internal, _ = EncryptInternal(EncryptionKey, err)
+	}
+	if tenant, ok := p.TenantPolicies[ResolveTenant(ctx)]; ok && err != nil {
+		jsonError = applyTenantPolicy(p, tenant, err, jsonError)
+	}
+	return styleError(p, jsonError)
+}
+
+// applyTenantPolicy applies the overrides of the tenant policy to the formatted error
+func applyTenantPolicy(p *Config, policy TenantPolicy, err error, jsonError JSONError) JSONError {
+	if policy.Support != nil {
+		jsonError.Support = errorSupport(err, jsonError.StatusCode, policy.Support)
+	}
+	if policy.Redact {
+		jsonError.Message = errorMessage(p, &Error{Code: jsonError.Code})
+		jsonError.Details = nil
+	}
+	if message, ok := policy.Messages[jsonError.Code]; ok {
+		jsonError.Message = message
+	}
+	details := mergeMaps(nil, policy.Details)
+	if policy.Locale != "" {
+		details = mergeMaps(details, map[string]interface{}{FieldLocale: policy.Locale})
+	}
+	if policy.DocsURL != "" {
+		details = mergeMaps(details, map[string]interface{}{"docs_url": codeDocsURL(policy.DocsURL, jsonError.Code)})
+	}
+	if len(details) > 0 {
+		jsonError.Details = mergeMaps(mergeMaps(nil, jsonError.Details), details)
+	}
+	return jsonError
+}
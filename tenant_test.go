@@ -0,0 +1,69 @@
+package ergo
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatErrorContext(t *testing.T) {
+	defer func() { TenantPolicies = map[string]TenantPolicy{} }()
+	TenantPolicies["acme"] = TenantPolicy{
+		Messages: map[string]string{ENOTFOUND: "Acme could not find it."},
+		Details:  map[string]interface{}{"support": "help@acme.test"},
+	}
+	TenantPolicies["strict"] = TenantPolicy{Redact: true}
+	error := &Error{
+		Code:    ENOTFOUND,
+		Message: "user 42 not found",
+		Details: map[string]interface{}{"id": 42},
+	}
+
+	// Test without tenant
+	actual := FormatErrorContext(context.Background(), error)
+	assert.Equal(t, FormatError(error), actual)
+
+	// Test with tenant overrides
+	actual = FormatErrorContext(WithTenant(context.Background(), "acme"), error)
+	assert.Equal(t, "Acme could not find it.", actual.Message)
+	assert.Equal(t, map[string]interface{}{"id": 42, "support": "help@acme.test"}, actual.Details)
+
+	// Test with redaction
+	actual = FormatErrorContext(WithTenant(context.Background(), "strict"), error)
+	assert.Equal(t, "Resource not found.", actual.Message)
+	assert.Nil(t, actual.Details)
+
+	// Test with the locale and the documentation of the tenant
+	TenantPolicies["globex"] = TenantPolicy{
+		Messages: map[string]string{ENOTFOUND: "Ressource introuvable."},
+		Redact:   true,
+		Locale:   "fr-FR",
+		DocsURL:  "https://docs.globex.test/errors/",
+	}
+	actual = FormatErrorContext(WithTenant(context.Background(), "globex"), error)
+	assert.Equal(t, "Ressource introuvable.", actual.Message)
+	assert.Equal(t, map[string]interface{}{
+		FieldLocale: "fr-FR",
+		"docs_url":  "https://docs.globex.test/errors/not_found",
+	}, actual.Details)
+	assert.Equal(t, map[string]interface{}{"id": 42}, error.Details)
+
+	// Test that the documentation of the tenant overrides the one of the template
+	templated := Template{DocsURL: "https://docs.example.com"}.Apply(&Error{Code: EINVALID})
+	actual = FormatErrorContext(WithTenant(context.Background(), "globex"), templated)
+	assert.Equal(t, "https://docs.globex.test/errors/invalid", actual.Details["docs_url"])
+}
+
+func TestWriteErrorContext(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	defer func() { TenantPolicies = map[string]TenantPolicy{} }()
+	TenantPolicies["acme"] = TenantPolicy{
+		Details: map[string]interface{}{"brand": "acme"},
+	}
+
+	recorder := httptest.NewRecorder()
+	WriteErrorContext(WithTenant(context.Background(), "acme"), recorder, &Error{Code: EINVALID})
+	assert.JSONEq(t, `{"code":"invalid","error_id":"err-1","code_id":2,"status_code":400,"message":"Bad request.","details":{"brand":"acme"}}`, recorder.Body.String())
+}
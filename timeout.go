@@ -0,0 +1,33 @@
+package ergo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WithTimeout runs fn with a context whose deadline is d from now. If the deadline expires
+// before fn succeeds, it returns an ETIMEOUT error for the operation wrapping the error
+// of fn, with the budget and the elapsed time, in milliseconds, in the fields.
+// Otherwise it returns the error of fn.
+func WithTimeout(ctx context.Context, d time.Duration, op string, fn func(ctx context.Context) error) error {
+	start := now()
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	err := fn(ctx)
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return construct(&Error{
+		Code: ETIMEOUT,
+		// The message of the wrapped error must not reach the client
+		Message: policy().Codes[ETIMEOUT].Message,
+		Op:      op,
+		Err:     err,
+		Fields: map[string]interface{}{
+			"budget_ms":  d.Milliseconds(),
+			"elapsed_ms": now().Sub(start).Milliseconds(),
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package ergo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeout(t *testing.T) {
+	// Test with an operation completing in time
+	err := WithTimeout(context.Background(), time.Second, "user.Get", func(ctx context.Context) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// Test with a failing operation
+	failure := &Error{Code: ENOTFOUND}
+	err = WithTimeout(context.Background(), time.Second, "user.Get", func(ctx context.Context) error {
+		return failure
+	})
+	assert.Equal(t, failure, err)
+
+	// Test with an expired deadline
+	err = WithTimeout(context.Background(), 10*time.Millisecond, "report.Generate", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.Equal(t, ETIMEOUT, ErrorCode(err))
+	assert.Equal(t, 504, ErrorStatusCode(err))
+	assert.Equal(t, "The operation timed out.", ErrorMessage(err))
+	assert.Equal(t, "report.Generate: context deadline exceeded", err.Error())
+	assert.True(t, errors.Is(err.(*Error).Err, context.DeadlineExceeded))
+	fields := ErrorFields(err)
+	assert.Equal(t, int64(10), fields["budget_ms"])
+	assert.GreaterOrEqual(t, fields["elapsed_ms"].(int64), int64(10))
+}
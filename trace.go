@@ -0,0 +1,73 @@
+package ergo
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Op returns the logical operation of the error, the outermost one of the stack, e.g. the
+// one inferred by Trace, or an empty string.
+func Op(err error) string {
+	return errorOp(err)
+}
+
+// CallerOp returns the logical operation of the calling function, as package.Func
+// or package.Type.Method, e.g. to set the Op of an error built by hand.
+func CallerOp() string {
+	op := callerOp(2)
+	checkOp(op)
+	return op
+}
+
+// Trace wraps the error with the logical operation of the calling function as Op.
+// It returns nil if err is nil.
+func Trace(err error) error {
+	if err == nil {
+		return nil
+	}
+	return construct(&Error{Op: callerOp(2), Err: err})
+}
+
+// callerOp returns the operation of the function skip frames above it
+func callerOp(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	return opName(fn.Name())
+}
+
+// opName converts a function name such as github.com/org/repo/user.(*Service).Create
+// to user.Service.Create. The type parameters of the generic functions and types, such
+// as the [...] of user.(*Cache[...]).Get, are removed.
+func opName(name string) string {
+	name = stripTypeParams(name)
+	if slash := strings.LastIndex(name, "/"); slash >= 0 {
+		name = name[slash+1:]
+	}
+	return strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+}
+
+// stripTypeParams removes the bracketed type parameters of the function name
+func stripTypeParams(name string) string {
+	if !strings.Contains(name, "[") {
+		return name
+	}
+	var stripped strings.Builder
+	depth := 0
+	for _, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			stripped.WriteRune(r)
+		}
+	}
+	return stripped.String()
+}
@@ -0,0 +1,45 @@
+package ergo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type traceService struct{}
+
+func (s *traceService) Create() error {
+	return Trace(errors.New("some error"))
+}
+
+func TestOp(t *testing.T) {
+	assert.Equal(t, "ergo.traceService.Create", Op((&traceService{}).Create()))
+	assert.Equal(t, "user.Get", Op(&Error{Op: "user.Get", Err: &Error{Op: "db.Get"}}))
+	assert.Equal(t, "", Op(errors.New("some error")))
+	assert.Equal(t, "", Op(nil))
+}
+
+func TestCallerOp(t *testing.T) {
+	assert.Equal(t, "ergo.TestCallerOp", CallerOp())
+}
+
+func TestTrace(t *testing.T) {
+	// Test with nil error
+	assert.Nil(t, Trace(nil))
+
+	err := (&traceService{}).Create()
+	assert.Equal(t, "ergo.traceService.Create: some error", err.Error())
+	assert.Equal(t, EINTERNAL, ErrorCode(err))
+}
+
+func TestOpName(t *testing.T) {
+	assert.Equal(t, "user.Service.Create", opName("github.com/org/repo/user.(*Service).Create"))
+	assert.Equal(t, "user.Create.func1", opName("github.com/org/repo/user.Create.func1"))
+	assert.Equal(t, "main.main", opName("main.main"))
+
+	// Test with the generic functions and types
+	assert.Equal(t, "user.Cache.Get", opName("github.com/org/repo/user.(*Cache[...]).Get"))
+	assert.Equal(t, "user.Map.func1", opName("github.com/org/repo/user.Map[...].func1"))
+	assert.Equal(t, "user.Load", opName("github.com/org/repo/user.Load[go.shape.*github.com/org/repo/user.User]"))
+}
@@ -0,0 +1,95 @@
+package ergo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// RunInTx runs fn in a transaction of the database, committed if fn succeeds and rolled
+// back otherwise. Serialization failures and deadlocks become ECONFLICT errors tagged
+// with TagRetryable, so that Retry runs the transaction again, and the failures to begin
+// or commit the transaction become EINTERNAL errors. Other errors of fn are returned
+// unchanged, with the failure of the rollback, if any, in the fields. The transaction is
+// also rolled back when fn panics, before the panic is propagated.
+func RunInTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return construct(&Error{Code: EINTERNAL, Op: "ergo.RunInTx", Err: err})
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		rollbackErr := tx.Rollback()
+		if serializationFailure(err) {
+			err = txConflict(err)
+		}
+		if rollbackErr != nil {
+			err = &Error{Err: err, Fields: map[string]interface{}{"rollback_error": rollbackErr.Error()}}
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		if serializationFailure(err) {
+			return txConflict(err)
+		}
+		// The outcome of the transaction is unknown, so it must not be run again
+		return construct(&Error{Code: EINTERNAL, Op: "ergo.RunInTx", Err: err, Tags: []string{tagCommitFailed}})
+	}
+	return nil
+}
+
+// tagCommitFailed marks the failed commits, which Retry never retries
+const tagCommitFailed = "commit_failed"
+
+// Retry runs fn up to attempts times, at least once, while it returns a retryable error,
+// i.e. one with a retryable code, e.g. EUNAVAILABLE, or tagged with TagRetryable, waiting delay
+// before the first retry and doubling it afterwards. It stops when the context is done.
+// The failed commits of RunInTx are never retried, since the transaction may have been committed.
+func Retry(ctx context.Context, attempts int, delay time.Duration, fn func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil || !ErrorRetryable(err) || MatchTag(tagCommitFailed)(err) || attempt >= attempts {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// txConflict returns the retryable conflict of a serialization failure
+func txConflict(err error) *Error {
+	return construct(&Error{
+		Code:    ECONFLICT,
+		Message: "The transaction conflicted with a concurrent one.",
+		Op:      "ergo.RunInTx",
+		Err:     err,
+		Tags:    []string{TagRetryable},
+	})
+}
+
+// serializationFailure reports whether the error is a serialization failure or a deadlock,
+// from its SQLSTATE (40001 and 40P01), as exposed by the PostgreSQL drivers.
+// The errors without SQLSTATE are never serialization failures, whatever their message.
+func serializationFailure(err error) bool {
+	var sqlState interface{ SQLState() string }
+	if !errors.As(err, &sqlState) {
+		return false
+	}
+	state := sqlState.SQLState()
+	return state == "40001" || state == "40P01"
+}
@@ -0,0 +1,177 @@
+package ergo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// txDriver is a database driver whose transactions fail with commitErr
+type txDriver struct {
+	commitErr error
+	rollbacks int
+}
+
+type txConn struct {
+	driver *txDriver
+}
+
+type txTx struct {
+	driver *txDriver
+}
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string {
+	return "pq: could not complete the transaction"
+}
+
+func (e sqlStateError) SQLState() string {
+	return string(e)
+}
+
+func (d *txDriver) Open(name string) (driver.Conn, error) {
+	return &txConn{driver: d}, nil
+}
+
+func (c *txConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *txConn) Close() error {
+	return nil
+}
+
+func (c *txConn) Begin() (driver.Tx, error) {
+	return &txTx{driver: c.driver}, nil
+}
+
+func (t *txTx) Commit() error {
+	return t.driver.commitErr
+}
+
+func (t *txTx) Rollback() error {
+	t.driver.rollbacks++
+	return nil
+}
+
+var testTxDriver = &txDriver{}
+
+func init() {
+	sql.Register("ergo-tx", testTxDriver)
+}
+
+func TestRunInTx(t *testing.T) {
+	db, _ := sql.Open("ergo-tx", "")
+	defer db.Close()
+	defer func() { testTxDriver.commitErr = nil }()
+	ctx := context.Background()
+
+	// Test with a committed transaction
+	assert.NoError(t, RunInTx(ctx, db, nil, func(tx *sql.Tx) error { return nil }))
+
+	// Test with an error of the function
+	failure := &Error{Code: EINVALID}
+	assert.Equal(t, failure, RunInTx(ctx, db, nil, func(tx *sql.Tx) error { return failure }))
+
+	// Test with a serialization failure
+	testTxDriver.commitErr = sqlStateError("40001")
+	err := RunInTx(ctx, db, nil, func(tx *sql.Tx) error { return nil })
+	assert.Equal(t, ECONFLICT, ErrorCode(err))
+	assert.True(t, ErrorRetryable(err))
+
+	// Test with a deadlock
+	testTxDriver.commitErr = nil
+	err = RunInTx(ctx, db, nil, func(tx *sql.Tx) error { return sqlStateError("40P01") })
+	assert.Equal(t, ECONFLICT, ErrorCode(err))
+
+	// Test with a message mentioning a deadlock, without SQLSTATE
+	failed := errors.New("user deadlock_id 40001 not found")
+	assert.Equal(t, failed, RunInTx(ctx, db, nil, func(tx *sql.Tx) error { return failed }))
+
+	// Test with a panic of the function
+	rollbacks := testTxDriver.rollbacks
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = RunInTx(ctx, db, nil, func(tx *sql.Tx) error { panic("boom") })
+	})
+	assert.Equal(t, rollbacks+1, testTxDriver.rollbacks)
+
+	// Test with a failed commit
+	testTxDriver.commitErr = errors.New("connection reset")
+	err = RunInTx(ctx, db, nil, func(tx *sql.Tx) error { return nil })
+	assert.Equal(t, EINTERNAL, ErrorCode(err))
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+
+	// Test with a conflict resolved by a retry
+	attempts := 0
+	err := Retry(ctx, 3, time.Millisecond, func(ctx context.Context) error {
+		attempts++
+		if attempts < 2 {
+			return txConflict(errors.New("could not serialize access"))
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	// Test with a non-retryable error
+	attempts = 0
+	err = Retry(ctx, 3, time.Millisecond, func(ctx context.Context) error {
+		attempts++
+		return &Error{Code: EINVALID}
+	})
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, 1, attempts)
+
+	// Test with the attempts exhausted
+	attempts = 0
+	err = Retry(ctx, 3, time.Millisecond, func(ctx context.Context) error {
+		attempts++
+		return &Error{Code: EUNAVAILABLE}
+	})
+	assert.Equal(t, EUNAVAILABLE, ErrorCode(err))
+	assert.Equal(t, 3, attempts)
+
+	// Test with an unclassified error, not retried
+	attempts = 0
+	err = Retry(ctx, 3, time.Millisecond, func(ctx context.Context) error {
+		attempts++
+		return errors.New("pq: duplicate key value violates unique constraint")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	// Test with no attempts, running fn once
+	attempts = 0
+	err = Retry(ctx, 0, time.Millisecond, func(ctx context.Context) error {
+		attempts++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryCommitFailure(t *testing.T) {
+	db, _ := sql.Open("ergo-tx", "")
+	defer db.Close()
+	defer func() { testTxDriver.commitErr = nil }()
+	ctx := context.Background()
+
+	// Test with a failed commit tagged retryable by the driver, never retried
+	testTxDriver.commitErr = &Error{Code: EUNAVAILABLE, Tags: []string{TagRetryable}}
+	attempts := 0
+	err := Retry(ctx, 3, time.Millisecond, func(ctx context.Context) error {
+		attempts++
+		return RunInTx(ctx, db, nil, func(tx *sql.Tx) error { return nil })
+	})
+	assert.Equal(t, EINTERNAL, ErrorCode(err))
+	assert.Equal(t, 1, attempts)
+}
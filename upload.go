@@ -0,0 +1,108 @@
+package ergo
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+)
+
+// MissingFilePart returns an EINVALID error for a file part missing from the multipart form
+func MissingFilePart(part string) *Error {
+	return construct(&Error{
+		Code:    EINVALID,
+		Message: fmt.Sprintf("File %s is required.", part),
+		Details: map[string]interface{}{
+			"part":       part,
+			"constraint": ConstraintRequired,
+		},
+	})
+}
+
+// FileTooLarge returns an EPAYLOADTOOLARGE error for a file part exceeding limit bytes
+func FileTooLarge(part string, limit int64) *Error {
+	return construct(&Error{
+		Code:    EPAYLOADTOOLARGE,
+		Message: fmt.Sprintf("File %s must not exceed %d bytes.", part, limit),
+		Details: map[string]interface{}{
+			"part":  part,
+			"limit": limit,
+		},
+	})
+}
+
+// UnsupportedFileType returns an EINVALID error for the Content-Type of a file part,
+// listing the supported media types.
+func UnsupportedFileType(part string, contentType string, supported ...string) *Error {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+	details := map[string]interface{}{
+		"part":         part,
+		"content_type": contentType,
+	}
+	if len(supported) > 0 {
+		details["supported"] = supported
+	}
+	return construct(&Error{
+		Code:    EINVALID,
+		Message: fmt.Sprintf("File %s has an unsupported type.", part),
+		Details: details,
+	})
+}
+
+// FromMultipartError converts the error returned while reading the part of a multipart form,
+// or nil. Bodies exceeding http.MaxBytesReader are converted by FromMaxBytesError.
+func FromMultipartError(part string, err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.Is(err, http.ErrMissingFile) {
+		e = MissingFilePart(part)
+	} else if errors.Is(err, multipart.ErrMessageTooLarge) {
+		e = construct(&Error{
+			Code:    EPAYLOADTOOLARGE,
+			Message: "Multipart form is too large.",
+			Details: map[string]interface{}{
+				"part": part,
+			},
+		})
+	} else {
+		e = construct(&Error{
+			Code:    EINVALID,
+			Message: "Request body must be a valid multipart form.",
+			Details: map[string]interface{}{
+				"part": part,
+			},
+		})
+	}
+	e.Err = err
+	return e
+}
+
+// FormFile returns the file part of the multipart form, checking its size against limit
+// when it is positive and its Content-Type against the supported media types, if any.
+func FormFile(r *http.Request, part string, limit int64, supported ...string) (multipart.File, *multipart.FileHeader, error) {
+	file, header, err := r.FormFile(part)
+	if err != nil {
+		return nil, nil, FromMultipartError(part, err)
+	}
+	if limit > 0 && header.Size > limit {
+		_ = file.Close()
+		return nil, nil, FileTooLarge(part, limit)
+	}
+	if len(supported) > 0 {
+		contentType := header.Header.Get("Content-Type")
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		for _, supportedType := range supported {
+			if mediaType == supportedType {
+				return file, header, nil
+			}
+		}
+		_ = file.Close()
+		return nil, nil, UnsupportedFileType(part, contentType, supported...)
+	}
+	return file, header, nil
+}
@@ -0,0 +1,67 @@
+package ergo
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormFile(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="avatar"; filename="avatar.png"`)
+	header.Set("Content-Type", "image/png")
+	part, _ := writer.CreatePart(header)
+	_, _ = part.Write([]byte("png content"))
+	_ = writer.Close()
+	request := func() *http.Request {
+		r := httptest.NewRequest("POST", "/upload", bytes.NewReader(body.Bytes()))
+		r.Header.Set("Content-Type", writer.FormDataContentType())
+		return r
+	}
+
+	// Test with a valid file
+	file, fileHeader, err := FormFile(request(), "avatar", 1024, "image/png", "image/jpeg")
+	assert.NoError(t, err)
+	assert.Equal(t, "avatar.png", fileHeader.Filename)
+	_ = file.Close()
+
+	// Test with a missing part
+	_, _, err = FormFile(request(), "document", 1024)
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "File document is required.", ErrorMessage(err))
+	assert.Equal(t, map[string]interface{}{"part": "document", "constraint": ConstraintRequired}, ErrorDetails(err))
+
+	// Test with a file too large
+	_, _, err = FormFile(request(), "avatar", 4)
+	assert.Equal(t, EPAYLOADTOOLARGE, ErrorCode(err))
+	assert.Equal(t, map[string]interface{}{"part": "avatar", "limit": int64(4)}, ErrorDetails(err))
+
+	// Test with an unsupported type
+	_, _, err = FormFile(request(), "avatar", 0, "image/jpeg")
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "image/png", ErrorDetails(err)["content_type"])
+	assert.Equal(t, []string{"image/jpeg"}, ErrorDetails(err)["supported"])
+
+	// Test with a body that is not multipart
+	r := httptest.NewRequest("POST", "/upload", strings.NewReader("{}"))
+	r.Header.Set("Content-Type", "application/json")
+	_, _, err = FormFile(r, "avatar", 0)
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "Request body must be a valid multipart form.", ErrorMessage(err))
+}
+
+func TestFromMultipartError(t *testing.T) {
+	assert.Nil(t, FromMultipartError("avatar", nil))
+
+	err := FromMultipartError("avatar", multipart.ErrMessageTooLarge)
+	assert.Equal(t, EPAYLOADTOOLARGE, ErrorCode(err))
+	assert.Equal(t, multipart.ErrMessageTooLarge, err.Err)
+}
@@ -0,0 +1,149 @@
+package ergo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// maxUpstreamBody is the maximum number of bytes read from the body of upstream errors
+const maxUpstreamBody = 1 << 20
+
+// Hop defines a service an error has been propagated from
+type Hop struct {
+	Service string    `json:"service"`
+	Time    time.Time `json:"time"`
+}
+
+// FromResponse returns the error of a response received from an upstream service,
+// or nil if the response is not an error.
+// Code, message, details and error_id of ergo error bodies are preserved and a hop is appended
+// for the upstream service. Other responses are classified by their status code.
+// The upstream request and status are attached to the fields, with the latency when
+// the response was received through Transport.
+// The body of the response is read but not closed.
+func FromResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	var upstream JSONError
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxUpstreamBody))
+	if json.Unmarshal(body, &upstream) != nil || upstream.Code == "" {
+		upstream = JSONError{Code: statusErrorCode(resp.StatusCode)}
+	}
+	if upstream.Message == "" {
+		upstream.Message = ErrorMessage(&Error{Code: upstream.Code})
+	}
+
+	service := ""
+	if upstream.Origin != nil {
+		service = upstream.Origin.Service
+	} else if resp.Request != nil {
+		service = resp.Request.URL.Host
+	}
+
+	fields := upstreamFields(resp.Request, 0)
+	fields["upstream_status"] = resp.StatusCode
+	if body, ok := resp.Body.(*timedBody); ok {
+		fields["upstream_latency_ms"] = body.latency.Milliseconds()
+	}
+	cause := ""
+	if resp.StatusCode >= http.StatusInternalServerError {
+		cause = CauseDependencyFailure
+	}
+
+	return &Error{
+		Code:    upstream.Code,
+		Message: upstream.Message,
+		Details: upstream.Details,
+		Hops:    append(upstream.Hops, Hop{Service: service, Time: now().UTC()}),
+		Fields:  fields,
+		Cause:   cause,
+		Err:     fmt.Errorf("upstream %s responded %s", service, resp.Status),
+		ErrorID: upstream.ErrorID,
+	}
+}
+
+// Transport is an http.RoundTripper measuring the latency of the upstream requests,
+// reported by FromResponse, and converting their failures into errors carrying the
+// upstream request in the fields.
+type Transport struct {
+	// Base is the RoundTripper used to send the requests, http.DefaultTransport if nil
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := now()
+	resp, err := base.RoundTrip(req)
+	latency := now().Sub(start)
+	if err != nil {
+		return nil, &Error{
+			Code:   EINTERNAL,
+			Op:     "ergo.Transport",
+			Cause:  CauseDependencyFailure,
+			Fields: upstreamFields(req, latency),
+			Err:    err,
+		}
+	}
+	resp.Body = &timedBody{ReadCloser: resp.Body, latency: latency}
+	return resp, nil
+}
+
+// timedBody carries the latency of the response it is the body of
+type timedBody struct {
+	io.ReadCloser
+	latency time.Duration
+}
+
+// upstreamFields returns the fields describing the upstream request
+func upstreamFields(req *http.Request, latency time.Duration) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if req != nil {
+		fields["upstream_host"] = req.URL.Host
+		fields["upstream_method"] = req.Method
+		fields["upstream_path"] = req.URL.Path
+	}
+	if latency > 0 {
+		fields["upstream_latency_ms"] = latency.Milliseconds()
+	}
+	return fields
+}
+
+// ErrorHops returns the hops the error has been propagated from, if available
+func ErrorHops(err error) []Hop {
+	if e, isCustomError := err.(*Error); isCustomError && len(e.Hops) > 0 {
+		return e.Hops
+	} else if isCustomError && e.Err != nil {
+		return ErrorHops(e.Err)
+	}
+	return nil
+}
+
+// statusErrorCode returns the registered code of the status code.
+// Otherwise returns EINTERNAL.
+func statusErrorCode(status int) string {
+	codes := policy().Codes
+	code, codeID := "", 0
+	for _, candidate := range sortedKeys(codes) {
+		info := codes[candidate]
+		// Prefer the code with the lowest ID when several share the status code, then the
+		// first code in order, the codes without ID coming last
+		if info.Status == status && (code == "" || (info.ID != 0 && (codeID == 0 || info.ID < codeID))) {
+			code, codeID = candidate, info.ID
+		}
+	}
+	if code == "" {
+		return EINTERNAL
+	}
+	return code
+}
@@ -0,0 +1,115 @@
+package ergo
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func upstreamResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    httptest.NewRequest(http.MethodGet, "http://users.internal/users/42", nil),
+	}
+}
+
+func TestFromResponse(t *testing.T) {
+	// Test with successful response
+	assert.Nil(t, FromResponse(upstreamResponse(http.StatusOK, "{}")))
+
+	// Test with ergo error body
+	body := `{"code":"not_found","error_id":"01HQWY5CG074YFX55QR0RC0ZCB","status_code":404,"message":"user not found","details":{"id":42},` +
+		`"origin":{"service":"users"},"hops":[{"service":"profiles","time":"2020-06-01T12:00:00Z"}]}`
+	err := FromResponse(upstreamResponse(http.StatusNotFound, body))
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	assert.Equal(t, "user not found", ErrorMessage(err))
+	assert.Equal(t, float64(42), ErrorDetails(err)["id"])
+	assert.Equal(t, "upstream users responded Not Found", err.Error())
+	assert.Equal(t, "01HQWY5CG074YFX55QR0RC0ZCB", ErrorID(err))
+	assert.Equal(t, "01HQWY5CG074YFX55QR0RC0ZCB", FormatError(&Error{Op: "profile.Get", Err: err}).ErrorID)
+
+	hops := ErrorHops(err)
+	assert.Len(t, hops, 2)
+	assert.Equal(t, Hop{Service: "profiles", Time: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)}, hops[0])
+	assert.Equal(t, "users", hops[1].Service)
+	assert.Equal(t, hops, FormatError(&Error{Op: "profile.Get", Err: err}).Hops)
+
+	// Test with other body
+	err = FromResponse(upstreamResponse(http.StatusForbidden, "<html>denied</html>"))
+	assert.Equal(t, EFORBIDDEN, ErrorCode(err))
+	assert.Equal(t, "Forbidden.", ErrorMessage(err))
+	assert.Equal(t, "users.internal", ErrorHops(err)[0].Service)
+	assert.Equal(t, "", ErrorID(err))
+
+	err = FromResponse(upstreamResponse(http.StatusBadGateway, ""))
+	assert.Equal(t, EINTERNAL, ErrorCode(err))
+}
+
+func TestStatusErrorCode(t *testing.T) {
+	defer resetConfig()
+
+	// Test with codes sharing the status code, the lowest ID first, then the first code
+	codes := Mapping()
+	codes["gone"] = CodeInfo{ID: 100, Status: http.StatusForbidden, Message: "Gone."}
+	codes["blocked"] = CodeInfo{Status: http.StatusForbidden, Message: "Blocked."}
+	codes["banned"] = CodeInfo{Status: http.StatusForbidden, Message: "Banned."}
+	assert.NoError(t, Update(Config{Codes: codes}))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, EFORBIDDEN, statusErrorCode(http.StatusForbidden))
+	}
+
+	delete(codes, EFORBIDDEN)
+	delete(codes, "gone")
+	assert.NoError(t, Update(Config{Codes: codes}))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "banned", statusErrorCode(http.StatusForbidden))
+	}
+}
+
+func TestFromResponseFields(t *testing.T) {
+	err := FromResponse(upstreamResponse(http.StatusBadGateway, ""))
+	expected := map[string]interface{}{
+		"upstream_host":   "users.internal",
+		"upstream_method": http.MethodGet,
+		"upstream_path":   "/users/42",
+		"upstream_status": http.StatusBadGateway,
+	}
+	assert.Equal(t, expected, ErrorFields(err))
+	assert.Equal(t, CauseDependencyFailure, ErrorCause(err))
+
+	// Test that the fields are not sent to the client
+	assert.Nil(t, FormatError(err).Details)
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, &Error{Code: ENOTFOUND})
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+	resp, err := client.Get(server.URL + "/users/42")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	err = FromResponse(resp)
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	fields := ErrorFields(err)
+	assert.Equal(t, "/users/42", fields["upstream_path"])
+	assert.Contains(t, fields, "upstream_latency_ms")
+
+	// Test with an upstream failure
+	server.Close()
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/users/42", nil)
+	_, err = (&Transport{}).RoundTrip(req)
+	assert.Equal(t, EINTERNAL, ErrorCode(err))
+	assert.Equal(t, CauseDependencyFailure, ErrorCause(err))
+	assert.Equal(t, http.MethodGet, ErrorFields(err)["upstream_method"])
+}
@@ -0,0 +1,46 @@
+package ergo
+
+import "fmt"
+
+// Constraints violated by the fields of a request
+const (
+	ConstraintRequired  = "required"
+	ConstraintMaxLength = "max_length"
+	ConstraintRange     = "range"
+)
+
+// FieldRequired returns an EINVALID error for a missing field
+func FieldRequired(field string) *Error {
+	return fieldError(field, ConstraintRequired, fmt.Sprintf("%s is required.", field), nil)
+}
+
+// FieldTooLong returns an EINVALID error for a field longer than max
+func FieldTooLong(field string, max int) *Error {
+	return fieldError(field, ConstraintMaxLength, fmt.Sprintf("%s must be at most %d characters long.", field, max), map[string]interface{}{
+		"max": max,
+	})
+}
+
+// FieldOutOfRange returns an EINVALID error for a field outside min..max
+func FieldOutOfRange(field string, min int, max int) *Error {
+	return fieldError(field, ConstraintRange, fmt.Sprintf("%s must be between %d and %d.", field, min, max), map[string]interface{}{
+		"min": min,
+		"max": max,
+	})
+}
+
+// fieldError returns an EINVALID error whose details describe the violated constraint
+func fieldError(field string, constraint string, message string, params map[string]interface{}) *Error {
+	details := map[string]interface{}{
+		"field":      field,
+		"constraint": constraint,
+	}
+	for key, value := range params {
+		details[key] = value
+	}
+	return construct(&Error{
+		Code:    EINVALID,
+		Message: message,
+		Details: details,
+	})
+}
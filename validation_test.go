@@ -0,0 +1,30 @@
+package ergo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldRequired(t *testing.T) {
+	err := FieldRequired("email")
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "email is required.", ErrorMessage(err))
+	assert.Equal(t, map[string]interface{}{"field": "email", "constraint": ConstraintRequired}, ErrorDetails(err))
+}
+
+func TestFieldTooLong(t *testing.T) {
+	err := FieldTooLong("name", 255)
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "name must be at most 255 characters long.", ErrorMessage(err))
+	expected := map[string]interface{}{"field": "name", "constraint": ConstraintMaxLength, "max": 255}
+	assert.Equal(t, expected, ErrorDetails(err))
+}
+
+func TestFieldOutOfRange(t *testing.T) {
+	err := FieldOutOfRange("age", 0, 150)
+	assert.Equal(t, EINVALID, ErrorCode(err))
+	assert.Equal(t, "age must be between 0 and 150.", ErrorMessage(err))
+	expected := map[string]interface{}{"field": "age", "constraint": ConstraintRange, "min": 0, "max": 150}
+	assert.Equal(t, expected, ErrorDetails(err))
+}
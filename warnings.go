@@ -0,0 +1,72 @@
+package ergo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// Warning defines a non-fatal issue of a request that has succeeded, e.g. a degraded result
+type Warning struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// warnings collects the warnings of a request
+type warnings struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+type warningsKey struct{}
+
+// WithWarnings returns a copy of the context collecting the warnings of the request
+func WithWarnings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, warningsKey{}, &warnings{})
+}
+
+// Warn adds the error to the warnings of the context, formatted with FormatErrorContext.
+// The warning is discarded if the context does not collect warnings.
+func Warn(ctx context.Context, err error) {
+	collector, ok := ctx.Value(warningsKey{}).(*warnings)
+	if !ok || err == nil {
+		return
+	}
+	jsonError := FormatErrorContext(ctx, err)
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.warnings = append(collector.warnings, Warning{
+		Code:    jsonError.Code,
+		Message: jsonError.Message,
+		Details: jsonError.Details,
+	})
+}
+
+// Warnings returns the warnings collected in the context
+func Warnings(ctx context.Context) []Warning {
+	collector, ok := ctx.Value(warningsKey{}).(*warnings)
+	if !ok {
+		return nil
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	return append([]Warning(nil), collector.warnings...)
+}
+
+// WriteWithWarnings writes the data of a successful response under "data" and the warnings
+// collected in the context of the request under "warnings", if any.
+func WriteWithWarnings(w http.ResponseWriter, r *http.Request, status int, data interface{}) error {
+	body, err := json.Marshal(struct {
+		Data     interface{} `json:"data"`
+		Warnings []Warning   `json:"warnings,omitempty"`
+	}{Data: data, Warnings: Warnings(r.Context())})
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
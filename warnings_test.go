@@ -0,0 +1,31 @@
+package ergo
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnings(t *testing.T) {
+	// Test without collector
+	Warn(context.Background(), &Error{Code: EUNAVAILABLE})
+	assert.Nil(t, Warnings(context.Background()))
+
+	r := httptest.NewRequest("GET", "/dashboard", nil)
+	r = r.WithContext(WithWarnings(r.Context()))
+	Warn(r.Context(), &Error{Code: EUNAVAILABLE, Message: "Recommendations are unavailable.", Details: map[string]interface{}{"section": "recommendations"}})
+	Warn(r.Context(), nil)
+	assert.Len(t, Warnings(r.Context()), 1)
+
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, WriteWithWarnings(recorder, r, 200, map[string]int{"orders": 3}))
+	assert.Equal(t, 200, recorder.Code)
+	assert.JSONEq(t, `{"data":{"orders":3},"warnings":[{"code":"unavailable","message":"Recommendations are unavailable.","details":{"section":"recommendations"}}]}`, recorder.Body.String())
+
+	// Test without warnings
+	recorder = httptest.NewRecorder()
+	assert.NoError(t, WriteWithWarnings(recorder, httptest.NewRequest("GET", "/", nil), 200, "ok"))
+	assert.JSONEq(t, `{"data":"ok"}`, recorder.Body.String())
+}
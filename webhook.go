@@ -0,0 +1,136 @@
+package ergo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WebhookEvent defines a handled error as sent by Webhook.
+// Only the client representation of the error is sent, the wrapped error is discarded.
+type WebhookEvent struct {
+	Time        time.Time `json:"time"`
+	Op          string    `json:"op,omitempty"`
+	Cause       string    `json:"cause,omitempty"`
+	Fingerprint string    `json:"fingerprint"`
+	JSONError
+}
+
+// Webhook is a Reporter posting the handled errors in batches to URL.
+// Batches are posted when BatchSize events are collected and by Run, retried up to
+// MaxRetries times waiting RetryDelay, doubled at each attempt, and signed in
+// SignatureHeader when Key is set. Encode formats the body, {"events":[...]} by default.
+// It is safe for concurrent use.
+type Webhook struct {
+	URL        string
+	Key        []byte
+	Client     *http.Client
+	BatchSize  int
+	MaxRetries int
+	RetryDelay time.Duration
+	Encode     func(events []WebhookEvent) ([]byte, error)
+
+	mu     sync.Mutex
+	events []WebhookEvent
+}
+
+// NewWebhook returns a Webhook posting batches of 100 events, retried 3 times
+func NewWebhook(url string, key []byte) *Webhook {
+	return &Webhook{
+		URL:        url,
+		Key:        key,
+		Client:     http.DefaultClient,
+		BatchSize:  100,
+		MaxRetries: 3,
+		RetryDelay: time.Second,
+		Encode:     encodeWebhookEvents,
+	}
+}
+
+// Report adds the event of the error to the batch, posting it in background when full
+func (w *Webhook) Report(err error) {
+	event := WebhookEvent{
+		Time:        now().UTC(),
+		Op:          errorOp(err),
+		Cause:       ErrorCause(err),
+		Fingerprint: fingerprint(ErrorCode(err), errorOp(err)),
+		JSONError:   FormatError(err),
+	}
+
+	w.mu.Lock()
+	w.events = append(w.events, event)
+	full := w.BatchSize > 0 && len(w.events) >= w.BatchSize
+	w.mu.Unlock()
+	if full {
+		go func() { _ = w.Flush() }()
+	}
+}
+
+// Flush posts the collected events, if any
+func (w *Webhook) Flush() error {
+	w.mu.Lock()
+	events := w.events
+	w.events = nil
+	w.mu.Unlock()
+	if len(events) == 0 {
+		return nil
+	}
+
+	body, err := w.Encode(events)
+	if err != nil {
+		return err
+	}
+	delay := w.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err = w.post(body)
+		if err == nil || attempt >= w.MaxRetries {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// Run posts the collected events every interval until the context is done,
+// then posts the remaining ones
+func (w *Webhook) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = w.Flush()
+		case <-ctx.Done():
+			_ = w.Flush()
+			return
+		}
+	}
+}
+
+func (w *Webhook) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.Key) > 0 {
+		req.Header.Set(SignatureHeader, SignBody(w.Key, body))
+	}
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("ergo: webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+func encodeWebhookEvents(events []WebhookEvent) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"events": events})
+}
@@ -0,0 +1,91 @@
+package ergo
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookFlush(t *testing.T) {
+	key := []byte("secret")
+	var attempts int32
+	var received struct {
+		Events []WebhookEvent `json:"events"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt to test the retries
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.True(t, VerifySignature(key, body, r.Header.Get(SignatureHeader)))
+		assert.NoError(t, json.Unmarshal(body, &received))
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, key)
+	webhook.RetryDelay = time.Millisecond
+
+	// Test without events
+	assert.NoError(t, webhook.Flush())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&attempts))
+
+	webhook.Report(&Error{Code: ENOTFOUND, Op: "user.Get"})
+	webhook.Report(&Error{Code: EINVALID, Message: "secret data"})
+	assert.NoError(t, webhook.Flush())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Len(t, received.Events, 2)
+	assert.Equal(t, "user.Get", received.Events[0].Op)
+	assert.Equal(t, fingerprint(ENOTFOUND, "user.Get"), received.Events[0].Fingerprint)
+	assert.Equal(t, ENOTFOUND, received.Events[0].Code)
+}
+
+func TestWebhookRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, nil)
+	webhook.MaxRetries = 2
+	webhook.RetryDelay = time.Millisecond
+	webhook.Report(&Error{Code: EINTERNAL})
+	assert.EqualError(t, webhook.Flush(), "ergo: webhook responded 500 Internal Server Error")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestWebhookRun(t *testing.T) {
+	posted := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted <- struct{}{}
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		webhook.Run(ctx, time.Hour)
+		close(done)
+	}()
+
+	// Test that the remaining events are posted when the context is done
+	webhook.Report(&Error{Code: EINVALID})
+	cancel()
+	<-done
+	select {
+	case <-posted:
+	default:
+		t.Fatal("events not posted")
+	}
+}
@@ -0,0 +1,22 @@
+package ergo
+
+// WithCode returns a copy of the error with the code, leaving the error unchanged
+func (err *Error) WithCode(code string) *Error {
+	clone := err.Clone()
+	clone.Code = code
+	return clone
+}
+
+// WithMessage returns a copy of the error with the message, leaving the error unchanged
+func (err *Error) WithMessage(message string) *Error {
+	clone := err.Clone()
+	clone.Message = message
+	return clone
+}
+
+// WithOp returns a copy of the error with the operation, leaving the error unchanged
+func (err *Error) WithOp(op string) *Error {
+	clone := err.Clone()
+	clone.Op = op
+	return clone
+}
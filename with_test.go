@@ -0,0 +1,18 @@
+package ergo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWith(t *testing.T) {
+	shared := Sentinel(ENOTFOUND)
+
+	err := shared.WithCode(EGONE).WithMessage("User has been deleted.").WithOp("user.Get")
+	assert.Equal(t, &Error{Code: EGONE, Message: "User has been deleted.", Op: "user.Get"}, err)
+
+	// Test with the original error unchanged
+	assert.Equal(t, &Error{Code: ENOTFOUND, shared: true}, shared)
+	assert.Same(t, shared, Sentinel(ENOTFOUND))
+}
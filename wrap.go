@@ -0,0 +1,31 @@
+package ergo
+
+import "fmt"
+
+// Wrap wraps the error with the operation, keeping the code of the wrapped error, if any,
+// so annotating an error does not change its status code. It returns nil if err is nil.
+func Wrap(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+	return construct(wrap(err, op))
+}
+
+// Wrapf works like Wrap, also setting the message of the error formatted like fmt.Sprintf
+func Wrapf(err error, op string, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	e := wrap(err, op)
+	e.Message = fmt.Sprintf(format, args...)
+	return construct(e)
+}
+
+// wrap returns an error wrapping err with the operation and the code of err, if any
+func wrap(err error, op string) *Error {
+	e := &Error{Op: op, Err: err}
+	if hasCode(err) {
+		e.Code = ErrorCode(err)
+	}
+	return e
+}
@@ -0,0 +1,34 @@
+package ergo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	// Test with nil error
+	assert.Nil(t, Wrap(nil, "user.Get"))
+	assert.Nil(t, Wrapf(nil, "user.Get", "User %s not found.", "1"))
+
+	// Test with the code of the wrapped error
+	inner := &Error{Code: ENOTFOUND, Op: "db.Get"}
+	err := Wrap(inner, "user.Get")
+	assert.Equal(t, &Error{Code: ENOTFOUND, Op: "user.Get", Err: inner}, err)
+	assert.Equal(t, http.StatusNotFound, ErrorStatusCode(err))
+
+	err = Wrapf(inner, "user.Get", "User %s not found.", "1")
+	assert.Equal(t, ENOTFOUND, ErrorCode(err))
+	assert.Equal(t, "User 1 not found.", ErrorMessage(err))
+	assert.Equal(t, "user.Get: db.Get: <not_found>", err.Error())
+
+	// Test with an error without code
+	cause := errors.New("connection refused")
+	err = Wrap(cause, "user.Get")
+	assert.NotEmpty(t, err.(*Error).Stack)
+	err.(*Error).Stack = nil
+	assert.Equal(t, &Error{Op: "user.Get", Err: cause}, err)
+	assert.Equal(t, EINTERNAL, ErrorCode(err))
+}
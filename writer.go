@@ -0,0 +1,90 @@
+package ergo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// DefaultCacheControl is the Cache-Control header sent with error responses
+// whose code is not present in CacheControl.
+var DefaultCacheControl = "no-store"
+
+// CacheControl maps the application error codes to the Cache-Control header
+// sent with the error response, e.g. a short max-age for ENOTFOUND.
+// An empty value omits the header.
+var CacheControl = map[string]string{}
+
+// ErrorCacheControl returns the Cache-Control header of the error response
+func ErrorCacheControl(err error) string {
+	return errorCacheControl(policy(), err)
+}
+
+// errorCacheControl returns the Cache-Control header of the error response under the policy
+func errorCacheControl(p *Config, err error) string {
+	if value, ok := p.CacheControl[errorCode(p, err)]; ok {
+		return value
+	}
+	return DefaultCacheControl
+}
+
+// WriteError will write the Json representation of the error to the response and report the error
+func WriteError(w http.ResponseWriter, err error) {
+	WriteErrorContext(context.Background(), w, err)
+}
+
+// WriteErrorContext works like WriteError, formatting the error with FormatErrorContext
+func WriteErrorContext(ctx context.Context, w http.ResponseWriter, err error) {
+	err = Identify(err)
+	setSummary(ctx, err)
+	p := contextPolicy(ctx)
+	jsonError := formatErrorContext(p, ctx, err)
+	body, _ := json.Marshal(jsonError)
+	writeBody(w, nil, p, err, jsonError.StatusCode, "application/json; charset=utf-8", body)
+}
+
+// ServeError works like WriteErrorContext, using the context of the request and
+// negotiating the encoding of the error with the Accept header and its compression
+// with the Accept-Encoding header.
+// Trusted requests also receive the root cause of the error.
+func ServeError(w http.ResponseWriter, r *http.Request, err error) {
+	err = Identify(err)
+	setSummary(r.Context(), err)
+	p := contextPolicy(r.Context())
+	jsonError := formatErrorContext(p, r.Context(), err)
+	if err != nil && trusted(r) {
+		jsonError.RootCause = RootCause(err).Error()
+	}
+
+	if acceptsCompact(r) {
+		compactError := FormatCompactError(jsonError)
+		body, _ := json.Marshal(compactError)
+		writeBody(w, r, p, err, compactError.StatusCode, CompactMediaType, body)
+		return
+	}
+	body, _ := json.Marshal(jsonError)
+	writeBody(w, r, p, err, jsonError.StatusCode, "application/json; charset=utf-8", body)
+}
+
+// writeBody reports the error and writes the headers and the encoded body of the error response,
+// under the policy the body has been formatted with.
+// The body is compressed when the request, if any, accepts it.
+func writeBody(w http.ResponseWriter, r *http.Request, p *Config, err error, status int, contentType string, body []byte) {
+	report(err)
+
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	if cacheControl := errorCacheControl(p, err); cacheControl != "" {
+		header.Set("Cache-Control", cacheControl)
+	}
+	setRateLimitHeaders(header, err)
+	setRetryAfterHeader(header, err)
+	setErrorDeprecationHeaders(header, err)
+	setAllowHeader(header, err)
+	if len(SigningKey) > 0 {
+		header.Set(SignatureHeader, SignBody(SigningKey, body))
+	}
+	body = compressBody(header, r, body)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,41 @@
+package ergo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCacheControl(t *testing.T) {
+	defer func() { CacheControl = map[string]string{} }()
+
+	// Test with the default value
+	actual := ErrorCacheControl(errors.New("some error"))
+	assert.Equal(t, "no-store", actual)
+
+	// Test with a value for the Code
+	CacheControl[ENOTFOUND] = "public, max-age=60"
+	actual = ErrorCacheControl(&Error{Code: ENOTFOUND})
+	assert.Equal(t, "public, max-age=60", actual)
+}
+
+func TestWriteError(t *testing.T) {
+	defer useFixedIDs("err-1")()
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, &Error{Code: EINVALID, Message: "custom message"})
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"code":"invalid","error_id":"err-1","code_id":2,"status_code":400,"message":"custom message"}`, recorder.Body.String())
+
+	// Test without Cache-Control
+	defer func() { CacheControl = map[string]string{} }()
+	CacheControl[EINVALID] = ""
+	recorder = httptest.NewRecorder()
+	WriteError(recorder, &Error{Code: EINVALID})
+	assert.Empty(t, recorder.Header().Get("Cache-Control"))
+}